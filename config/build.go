@@ -0,0 +1,10 @@
+package config
+
+// Commit and BuildTime are set at link time, for example:
+//
+//	go build -ldflags "-X github.com/codepnw/sales-api/config.Commit=$(git rev-parse --short HEAD) \
+//		-X github.com/codepnw/sales-api/config.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+var (
+	Commit    = "unknown"
+	BuildTime = "unknown"
+)
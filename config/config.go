@@ -1,24 +1,32 @@
 package config
 
+import "time"
+
 type IConfig interface {
 	App() ConfigApp
 	DB() ConfigDB
+	Jwt() ConfigJwt
+	Log() ConfigLog
 }
 
 type config struct {
 	app *app
 	db  *db
+	jwt *jwt
+	log *log
 }
 
 // App Config
 type ConfigApp interface {
 	Port() string
 	Version() string
+	ShutdownTimeout() time.Duration
 }
 
 type app struct {
-	port    string
-	version string
+	port            string
+	version         string
+	shutdownTimeout time.Duration
 }
 
 // DB Config
@@ -26,23 +34,98 @@ type ConfigDB interface {
 	Driver() string
 	DSN() string
 	MaxOpenConn() int
+	MaxIdleConn() int
+	ConnMaxLifetime() time.Duration
+	ConnMaxIdleTime() time.Duration
+	AutoMigrate() bool
+	QueryTimeout() time.Duration
 }
 
 type db struct {
-	driver         string
-	dsn            string
-	maxConnections int
+	driver          string
+	dsn             string
+	maxConnections  int
+	maxIdle         int
+	connMaxLifetime time.Duration
+	connMaxIdleTime time.Duration
+	autoMigrate     bool
+	queryTimeout    time.Duration
+}
+
+// JWT Config
+type ConfigJwt interface {
+	AccessKey() []byte
+	RefreshKey() []byte
+	AccessExpiresAt() int
+	RefreshExpiresAt() int
+}
+
+type jwt struct {
+	accessKey        string
+	refreshKey       string
+	accessExpiresAt  int
+	refreshExpiresAt int
+}
+
+// Log Config
+type ConfigLog interface {
+	Level() string
+	Format() string
+	Output() string
+	MaxSize() int
+	MaxBackups() int
+	MaxAge() int
+	Compress() bool
+	SamplingInitial() int
+	SamplingThereafter() int
+}
+
+type log struct {
+	level              string
+	format             string
+	output             string
+	maxSize            int
+	maxBackups         int
+	maxAge             int
+	compress           bool
+	samplingInitial    int
+	samplingThereafter int
 }
 
 // Config Method
 func (c *config) App() ConfigApp { return c.app }
 func (c *config) DB() ConfigDB   { return c.db }
+func (c *config) Jwt() ConfigJwt { return c.jwt }
+func (c *config) Log() ConfigLog { return c.log }
 
 // App Method
-func (a *app) Port() string    { return a.port }
-func (a *app) Version() string { return a.version }
+func (a *app) Port() string                   { return a.port }
+func (a *app) Version() string                { return a.version }
+func (a *app) ShutdownTimeout() time.Duration { return a.shutdownTimeout }
 
 // DB Method
-func (d *db) DSN() string      { return d.dsn }
-func (d *db) Driver() string   { return d.driver }
-func (d *db) MaxOpenConn() int { return d.maxConnections }
+func (d *db) DSN() string                    { return d.dsn }
+func (d *db) Driver() string                 { return d.driver }
+func (d *db) MaxOpenConn() int               { return d.maxConnections }
+func (d *db) MaxIdleConn() int               { return d.maxIdle }
+func (d *db) ConnMaxLifetime() time.Duration { return d.connMaxLifetime }
+func (d *db) ConnMaxIdleTime() time.Duration { return d.connMaxIdleTime }
+func (d *db) AutoMigrate() bool              { return d.autoMigrate }
+func (d *db) QueryTimeout() time.Duration    { return d.queryTimeout }
+
+// JWT Method
+func (j *jwt) AccessKey() []byte     { return []byte(j.accessKey) }
+func (j *jwt) RefreshKey() []byte    { return []byte(j.refreshKey) }
+func (j *jwt) AccessExpiresAt() int  { return j.accessExpiresAt }
+func (j *jwt) RefreshExpiresAt() int { return j.refreshExpiresAt }
+
+// Log Method
+func (l *log) Level() string           { return l.level }
+func (l *log) Format() string          { return l.format }
+func (l *log) Output() string          { return l.output }
+func (l *log) MaxSize() int            { return l.maxSize }
+func (l *log) MaxBackups() int         { return l.maxBackups }
+func (l *log) MaxAge() int             { return l.maxAge }
+func (l *log) Compress() bool          { return l.compress }
+func (l *log) SamplingInitial() int    { return l.samplingInitial }
+func (l *log) SamplingThereafter() int { return l.samplingThereafter }
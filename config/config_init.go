@@ -17,22 +17,71 @@ func InitConfig(path, filename string) IConfig {
 	// example: APP_PORT=5000 go run .
 	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 
+	// example: db.query_timeout: 5s
+	viper.SetDefault("db.query_timeout", "10s")
+	// in-flight requests get this long to finish on SIGTERM/SIGINT
+	viper.SetDefault("app.shutdown_timeout", "15s")
+	viper.SetDefault("db.max_connections", 25)
+	viper.SetDefault("db.max_idle_connections", 25)
+	viper.SetDefault("db.conn_max_lifetime", "30m")
+	viper.SetDefault("db.conn_max_idle_time", "5m")
+	// example: log.format: console and log.level: debug for local runs,
+	// log.output takes stdout, stderr or a file path rotated at log.max_size MB
+	viper.SetDefault("log.level", "info")
+	viper.SetDefault("log.format", LogFormatJson)
+	viper.SetDefault("log.output", LogOutputStdout)
+	viper.SetDefault("log.max_size", 100)
+	viper.SetDefault("log.max_backups", 5)
+	viper.SetDefault("log.max_age", 30)
+	viper.SetDefault("log.sampling.initial", 100)
+	viper.SetDefault("log.sampling.thereafter", 100)
+
 	if err := viper.ReadInConfig(); err != nil {
 		logs.Error(err)
 		panic(err)
 	}
 
-	return &config{
+	cfg := &config{
 		app: &app{
-			port:    viper.GetString("app.port"),
-			version: viper.GetString("app.version"),
+			port:            viper.GetString("app.port"),
+			version:         viper.GetString("app.version"),
+			shutdownTimeout: viper.GetDuration("app.shutdown_timeout"),
 		},
 		db: &db{
-			driver:         viper.GetString("db.driver"),
-			dsn:            viper.GetString("db.dsn"),
-			maxConnections: viper.GetInt("db.max_connections"),
+			driver:          viper.GetString("db.driver"),
+			dsn:             viper.GetString("db.dsn"),
+			maxConnections:  viper.GetInt("db.max_connections"),
+			maxIdle:         viper.GetInt("db.max_idle_connections"),
+			connMaxLifetime: viper.GetDuration("db.conn_max_lifetime"),
+			connMaxIdleTime: viper.GetDuration("db.conn_max_idle_time"),
+			autoMigrate:     viper.GetBool("db.auto_migrate"),
+			queryTimeout:    viper.GetDuration("db.query_timeout"),
+		},
+		jwt: &jwt{
+			accessKey:        viper.GetString("jwt.access_key"),
+			refreshKey:       viper.GetString("jwt.refresh_key"),
+			accessExpiresAt:  viper.GetInt("jwt.access_expires"),
+			refreshExpiresAt: viper.GetInt("jwt.refresh_expires"),
 		},
+		log: &log{
+			level:              viper.GetString("log.level"),
+			format:             viper.GetString("log.format"),
+			output:             viper.GetString("log.output"),
+			maxSize:            viper.GetInt("log.max_size"),
+			maxBackups:         viper.GetInt("log.max_backups"),
+			maxAge:             viper.GetInt("log.max_age"),
+			compress:           viper.GetBool("log.compress"),
+			samplingInitial:    viper.GetInt("log.sampling.initial"),
+			samplingThereafter: viper.GetInt("log.sampling.thereafter"),
+		},
+	}
+
+	if err := InitLogger(cfg.log); err != nil {
+		logs.Error(err)
+		panic(err)
 	}
+
+	return cfg
 }
 
 func InitTimezone() {
@@ -0,0 +1,68 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/codepnw/sales-api/pkg/logs"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+const (
+	LogFormatJson    string = "json"
+	LogFormatConsole string = "console"
+	LogOutputStdout  string = "stdout"
+	LogOutputStderr  string = "stderr"
+)
+
+// InitLogger replaces the default logger of pkg/logs with the one described
+// by the log.* settings.
+func InitLogger(cfg ConfigLog) error {
+	level, err := zap.ParseAtomicLevel(cfg.Level())
+	if err != nil {
+		return err
+	}
+
+	var encoder zapcore.Encoder
+	switch cfg.Format() {
+	case LogFormatJson:
+		encoder = zapcore.NewJSONEncoder(logs.EncoderConfig())
+	case LogFormatConsole:
+		encoderConfig := logs.EncoderConfig()
+		encoderConfig.EncodeLevel = zapcore.CapitalLevelEncoder
+		encoder = zapcore.NewConsoleEncoder(encoderConfig)
+	default:
+		return fmt.Errorf("log format %q is not supported", cfg.Format())
+	}
+
+	var output zapcore.WriteSyncer
+	switch cfg.Output() {
+	case LogOutputStdout:
+		output = zapcore.Lock(os.Stdout)
+	case LogOutputStderr:
+		output = zapcore.Lock(os.Stderr)
+	default:
+		// any other value is a file path, rotated once it reaches log.max_size
+		output = zapcore.AddSync(&lumberjack.Logger{
+			Filename:   cfg.Output(),
+			MaxSize:    cfg.MaxSize(),
+			MaxBackups: cfg.MaxBackups(),
+			MaxAge:     cfg.MaxAge(),
+			Compress:   cfg.Compress(),
+		})
+	}
+
+	core := zapcore.NewCore(encoder, output, level)
+	// keep the first sampling.initial entries with the same message every
+	// second and then one in sampling.thereafter, 0 turns sampling off
+	if cfg.SamplingInitial() > 0 {
+		core = zapcore.NewSamplerWithOptions(core, time.Second, cfg.SamplingInitial(), cfg.SamplingThereafter())
+	}
+
+	logs.Replace(zap.New(core, zap.AddCaller(), zap.ErrorOutput(zapcore.Lock(os.Stderr))), level)
+
+	return nil
+}
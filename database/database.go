@@ -0,0 +1,86 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/codepnw/sales-api/config"
+)
+
+const (
+	DriverPostgres string = "postgres"
+	DriverMysql    string = "mysql"
+	DriverSqlite   string = "sqlite"
+	DriverMemory   string = "memory"
+)
+
+// queryTimeout bounds every repository call, see WithTimeout.
+var queryTimeout = 10 * time.Second
+
+// Connect opens the database selected by db.driver.
+func Connect(cfg config.IConfig) error {
+	if timeout := cfg.DB().QueryTimeout(); timeout > 0 {
+		queryTimeout = timeout
+	}
+
+	switch cfg.DB().Driver() {
+	case DriverPostgres:
+		return NewPostgresConnect(cfg)
+	case DriverMysql:
+		return NewMysqlConnect(cfg)
+	case DriverSqlite:
+		return NewSqliteConnect(cfg)
+	case DriverMemory:
+		return NewMemoryConnect(cfg)
+	}
+	return fmt.Errorf("db driver %q is not supported", cfg.DB().Driver())
+}
+
+// WithTimeout derives the context for one repository call from the request
+// context, so the query stops when the client goes away or db.query_timeout
+// passes, whichever comes first.
+func WithTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, queryTimeout)
+}
+
+// configurePool applies the db.* pool settings, a zero value keeps the
+// database/sql default.
+func configurePool(pool *sql.DB, cfg config.ConfigDB) {
+	if cfg.MaxOpenConn() > 0 {
+		pool.SetMaxOpenConns(cfg.MaxOpenConn())
+	}
+	if cfg.MaxIdleConn() > 0 {
+		pool.SetMaxIdleConns(cfg.MaxIdleConn())
+	}
+	if cfg.ConnMaxLifetime() > 0 {
+		pool.SetConnMaxLifetime(cfg.ConnMaxLifetime())
+	}
+	if cfg.ConnMaxIdleTime() > 0 {
+		pool.SetConnMaxIdleTime(cfg.ConnMaxIdleTime())
+	}
+}
+
+// Close releases whichever database Connect opened, it is called once the
+// http server has drained.
+func Close() error {
+	var errs []error
+
+	if dbPostgres != nil {
+		errs = append(errs, dbPostgres.Close())
+	}
+	if dbSqlite != nil {
+		errs = append(errs, dbSqlite.Close())
+	}
+	if dbMysql != nil {
+		pool, err := dbMysql.DB()
+		if err == nil {
+			err = pool.Close()
+		}
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
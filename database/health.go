@@ -0,0 +1,102 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// PoolStats is the part of sql.DBStats the readiness probe reports.
+type PoolStats struct {
+	MaxOpenConnections int    `json:"maxOpenConnections"`
+	OpenConnections    int    `json:"openConnections"`
+	InUse              int    `json:"inUse"`
+	Idle               int    `json:"idle"`
+	WaitCount          int64  `json:"waitCount"`
+	WaitDuration       string `json:"waitDuration"`
+	MaxIdleClosed      int64  `json:"maxIdleClosed"`
+	MaxIdleTimeClosed  int64  `json:"maxIdleTimeClosed"`
+	MaxLifetimeClosed  int64  `json:"maxLifetimeClosed"`
+}
+
+type Readiness struct {
+	Driver           string     `json:"driver"`
+	MigrationVersion uint       `json:"migrationVersion"`
+	Pool             *PoolStats `json:"pool,omitempty"`
+}
+
+// Ready pings the database behind driver and reads the applied migration
+// version, an error means the instance shouldn't receive traffic yet.
+func Ready(ctx context.Context, driver string) (*Readiness, error) {
+	ready := &Readiness{Driver: driver}
+
+	pool, err := Pool(driver)
+	if err != nil {
+		return nil, err
+	}
+	// the memory driver is ready as soon as it exists
+	if pool == nil {
+		return ready, nil
+	}
+
+	ctx, cancel := WithTimeout(ctx)
+	defer cancel()
+
+	if err := pool.PingContext(ctx); err != nil {
+		return nil, err
+	}
+
+	version, dirty, err := migrationVersion(ctx, pool)
+	if err != nil {
+		return nil, err
+	}
+	if dirty {
+		return nil, fmt.Errorf("database is dirty at version %d", version)
+	}
+	ready.MigrationVersion = version
+
+	stats := pool.Stats()
+	ready.Pool = &PoolStats{
+		MaxOpenConnections: stats.MaxOpenConnections,
+		OpenConnections:    stats.OpenConnections,
+		InUse:              stats.InUse,
+		Idle:               stats.Idle,
+		WaitCount:          stats.WaitCount,
+		WaitDuration:       stats.WaitDuration.String(),
+		MaxIdleClosed:      stats.MaxIdleClosed,
+		MaxIdleTimeClosed:  stats.MaxIdleTimeClosed,
+		MaxLifetimeClosed:  stats.MaxLifetimeClosed,
+	}
+
+	return ready, nil
+}
+
+// Pool returns the database/sql pool behind driver, the memory driver has none.
+func Pool(driver string) (*sql.DB, error) {
+	switch driver {
+	case DriverPostgres:
+		return dbPostgres.DB, nil
+	case DriverSqlite:
+		return dbSqlite.DB, nil
+	case DriverMysql:
+		return dbMysql.DB()
+	}
+	return nil, nil
+}
+
+// migrationVersion reads schema_migrations, which both the migration runner
+// and the golang-migrate CLI used for MySQL keep in the same layout.
+func migrationVersion(ctx context.Context, pool *sql.DB) (uint, bool, error) {
+	var version uint
+	var dirty bool
+
+	query := `SELECT version, dirty FROM schema_migrations LIMIT 1;`
+	if err := pool.QueryRowContext(ctx, query).Scan(&version, &dirty); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+
+	return version, dirty, nil
+}
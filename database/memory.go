@@ -0,0 +1,138 @@
+package database
+
+import (
+	"sync"
+
+	"github.com/codepnw/sales-api/config"
+	"github.com/codepnw/sales-api/pkg/logs"
+)
+
+var dbMemory *MemoryDB
+
+// MemoryDB keeps every table in process memory for the memory driver, it is
+// meant for tests and demos and starts empty on every run. Rows are stored by
+// value so a caller can't change them without going through a transaction.
+type MemoryDB struct {
+	mu        sync.RWMutex
+	tables    map[string]map[string]any
+	sequences map[string]int
+}
+
+// MemoryTx is the view a repository works with inside View or Update.
+type MemoryTx struct {
+	db       *MemoryDB
+	writable bool
+	undo     []func()
+}
+
+func NewMemoryDB() *MemoryDB {
+	return &MemoryDB{
+		tables:    make(map[string]map[string]any),
+		sequences: make(map[string]int),
+	}
+}
+
+// NewMemoryConnect warns on every start, nothing outlives the process and
+// replicas each see their own data, so db.driver: memory is no production
+// setting.
+func NewMemoryConnect(cfg config.IConfig) error {
+	dbMemory = NewMemoryDB()
+	logs.Warn("memory database created, every row is lost on restart and isn't shared between replicas, use it for tests and demos only")
+
+	return nil
+}
+
+func GetMemoryDB() *MemoryDB {
+	return dbMemory
+}
+
+// View runs fn under a read lock.
+func (db *MemoryDB) View(fn func(tx *MemoryTx) error) error {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	return fn(&MemoryTx{db: db})
+}
+
+// Update runs fn under the write lock and undoes its writes when it returns
+// an error, so each call behaves like one serializable transaction.
+func (db *MemoryDB) Update(fn func(tx *MemoryTx) error) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	tx := &MemoryTx{db: db, writable: true}
+	if err := fn(tx); err != nil {
+		for i := len(tx.undo) - 1; i >= 0; i-- {
+			tx.undo[i]()
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (tx *MemoryTx) Get(table, key string) (any, bool) {
+	row, ok := tx.db.tables[table][key]
+	return row, ok
+}
+
+// Rows returns every row of table in no particular order.
+func (tx *MemoryTx) Rows(table string) []any {
+	rows := make([]any, 0, len(tx.db.tables[table]))
+	for _, row := range tx.db.tables[table] {
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func (tx *MemoryTx) Put(table, key string, row any) {
+	tx.mustWrite()
+
+	rows, ok := tx.db.tables[table]
+	if !ok {
+		rows = make(map[string]any)
+		tx.db.tables[table] = rows
+	}
+
+	prev, existed := rows[key]
+	tx.undo = append(tx.undo, func() {
+		if existed {
+			rows[key] = prev
+		} else {
+			delete(rows, key)
+		}
+	})
+
+	rows[key] = row
+}
+
+// Delete removes the row and reports whether it existed.
+func (tx *MemoryTx) Delete(table, key string) bool {
+	tx.mustWrite()
+
+	rows := tx.db.tables[table]
+	prev, ok := rows[key]
+	if !ok {
+		return false
+	}
+
+	tx.undo = append(tx.undo, func() { rows[key] = prev })
+	delete(rows, key)
+
+	return true
+}
+
+// NextId returns the next value of sequence, like a database sequence it is
+// not given back when the transaction fails.
+func (tx *MemoryTx) NextId(sequence string) int {
+	tx.mustWrite()
+
+	tx.db.sequences[sequence]++
+	return tx.db.sequences[sequence]
+}
+
+func (tx *MemoryTx) mustWrite() {
+	if !tx.writable {
+		panic("database: write inside a MemoryDB.View")
+	}
+}
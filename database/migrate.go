@@ -0,0 +1,315 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/codepnw/sales-api/pkg/logs"
+	"github.com/jmoiron/sqlx"
+)
+
+//go:embed migrations/*.sql migrations/sqlite/*.sql
+var migrationFiles embed.FS
+
+// migrationLockId is the pg_advisory_lock key held while migrating, so only
+// one replica applies migrations at a time.
+const migrationLockId int64 = 7_001_245_913
+
+var migrationName = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version uint   `json:"version"`
+	Name    string `json:"name"`
+	up      string
+	down    string
+}
+
+type MigrationStatus struct {
+	Version    uint         `json:"version"`
+	Dirty      bool         `json:"dirty"`
+	Migrations []*Migration `json:"migrations"`
+}
+
+type Migrator struct {
+	db         *sqlx.DB
+	migrations []*Migration
+}
+
+// NewMigrator reads the embedded migrations, they follow the golang-migrate
+// naming and schema_migrations layout so an existing database keeps its version.
+// SQLite databases use the translated files in migrations/sqlite.
+func NewMigrator(db *sqlx.DB) (*Migrator, error) {
+	dir := "migrations"
+	if db.DriverName() == DriverSqlite {
+		dir = path.Join(dir, DriverSqlite)
+	}
+
+	files, err := fs.ReadDir(migrationFiles, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[uint]*Migration)
+	for _, f := range files {
+		match := migrationName.FindStringSubmatch(f.Name())
+		if match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := migrationFiles.ReadFile(path.Join(dir, f.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[uint(version)]
+		if !ok {
+			m = &Migration{Version: uint(version), Name: match[2]}
+			byVersion[uint(version)] = m
+		}
+
+		if match[3] == "up" {
+			m.up = string(body)
+		} else {
+			m.down = string(body)
+		}
+	}
+
+	migrations := make([]*Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.up == "" || m.down == "" {
+			return nil, fmt.Errorf("migration %d_%s needs both up and down files", m.Version, m.Name)
+		}
+		migrations = append(migrations, m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+func (m *Migrator) Latest() uint {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Up applies every migration that hasn't been applied yet.
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.To(ctx, m.Latest())
+}
+
+// Down rolls back the last applied migration.
+func (m *Migrator) Down(ctx context.Context) error {
+	return m.withLock(ctx, func(conn *sqlx.Conn) error {
+		current, err := m.current(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if current == 0 {
+			return nil
+		}
+
+		idx := m.index(current)
+		if idx < 0 {
+			return fmt.Errorf("database version %d has no migration file", current)
+		}
+		return m.down(ctx, conn, idx)
+	})
+}
+
+// To migrates up or down until the database is at version, 0 rolls back everything.
+func (m *Migrator) To(ctx context.Context, version uint) error {
+	if version != 0 && m.index(version) < 0 {
+		return fmt.Errorf("migration version %d not found", version)
+	}
+
+	return m.withLock(ctx, func(conn *sqlx.Conn) error {
+		current, err := m.current(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i, mig := range m.migrations {
+			if mig.Version > current && mig.Version <= version {
+				if err := m.up(ctx, conn, i); err != nil {
+					return err
+				}
+			}
+		}
+
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			mig := m.migrations[i]
+			if mig.Version <= current && mig.Version > version {
+				if err := m.down(ctx, conn, i); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+// Force sets the version without running anything, it is how a dirty
+// database is marked clean again after fixing it by hand.
+func (m *Migrator) Force(ctx context.Context, version uint) error {
+	return m.withLock(ctx, func(conn *sqlx.Conn) error {
+		return m.setVersion(ctx, conn, version, false)
+	})
+}
+
+func (m *Migrator) Status(ctx context.Context) (*MigrationStatus, error) {
+	if err := m.ensureTable(ctx, m.db); err != nil {
+		return nil, err
+	}
+
+	version, dirty, err := m.version(ctx, m.db)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MigrationStatus{Version: version, Dirty: dirty, Migrations: m.migrations}, nil
+}
+
+func (m *Migrator) index(version uint) int {
+	for i, mig := range m.migrations {
+		if mig.Version == version {
+			return i
+		}
+	}
+	return -1
+}
+
+func (m *Migrator) up(ctx context.Context, conn *sqlx.Conn, idx int) error {
+	mig := m.migrations[idx]
+	logs.Info(fmt.Sprintf("migrate up %d_%s", mig.Version, mig.Name))
+
+	if err := m.setVersion(ctx, conn, mig.Version, true); err != nil {
+		return err
+	}
+	if err := m.exec(ctx, conn, mig.up); err != nil {
+		return fmt.Errorf("migration %d_%s up: %w", mig.Version, mig.Name, err)
+	}
+	return m.setVersion(ctx, conn, mig.Version, false)
+}
+
+func (m *Migrator) down(ctx context.Context, conn *sqlx.Conn, idx int) error {
+	mig := m.migrations[idx]
+	logs.Info(fmt.Sprintf("migrate down %d_%s", mig.Version, mig.Name))
+
+	var prev uint
+	if idx > 0 {
+		prev = m.migrations[idx-1].Version
+	}
+
+	if err := m.setVersion(ctx, conn, prev, true); err != nil {
+		return err
+	}
+	if err := m.exec(ctx, conn, mig.down); err != nil {
+		return fmt.Errorf("migration %d_%s down: %w", mig.Version, mig.Name, err)
+	}
+	return m.setVersion(ctx, conn, prev, false)
+}
+
+// exec runs a migration file as one simple query, the files manage their own
+// BEGIN/COMMIT so a failed file is rolled back here.
+func (m *Migrator) exec(ctx context.Context, conn *sqlx.Conn, query string) error {
+	if _, err := conn.ExecContext(ctx, query); err != nil {
+		conn.ExecContext(ctx, `ROLLBACK;`)
+		return err
+	}
+	return nil
+}
+
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sqlx.Conn) error) error {
+	conn, err := m.db.Connx(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// a SQLite file is only ever opened by one process
+	if m.db.DriverName() != DriverSqlite {
+		if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1);`, migrationLockId); err != nil {
+			return err
+		}
+		defer conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1);`, migrationLockId)
+	}
+
+	if err := m.ensureTable(ctx, conn); err != nil {
+		return err
+	}
+
+	return fn(conn)
+}
+
+func (m *Migrator) ensureTable(ctx context.Context, db sqlx.ExecerContext) error {
+	query := `
+		CREATE TABLE IF NOT EXISTS "schema_migrations" (
+			"version" BIGINT NOT NULL PRIMARY KEY,
+			"dirty" BOOLEAN NOT NULL
+		);
+	`
+	_, err := db.ExecContext(ctx, query)
+	return err
+}
+
+func (m *Migrator) current(ctx context.Context, conn *sqlx.Conn) (uint, error) {
+	version, dirty, err := m.version(ctx, conn)
+	if err != nil {
+		return 0, err
+	}
+	if dirty {
+		return 0, fmt.Errorf("database is dirty at version %d, fix it and run migrate force", version)
+	}
+	return version, nil
+}
+
+func (m *Migrator) version(ctx context.Context, db sqlx.QueryerContext) (uint, bool, error) {
+	var version uint
+	var dirty bool
+
+	query := `SELECT "version", "dirty" FROM "schema_migrations" LIMIT 1;`
+	err := db.QueryRowxContext(ctx, query).Scan(&version, &dirty)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+
+	return version, dirty, nil
+}
+
+func (m *Migrator) setVersion(ctx context.Context, conn *sqlx.Conn, version uint, dirty bool) error {
+	tx, err := conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM "schema_migrations";`); err != nil {
+		return err
+	}
+
+	if version > 0 || dirty {
+		query := `INSERT INTO "schema_migrations" ("version", "dirty") VALUES ($1, $2);`
+		if _, err := tx.ExecContext(ctx, query, version, dirty); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,13 @@
+BEGIN;
+
+-- 'simple' keeps product names as typed, no stemming or stop words
+ALTER TABLE "products"
+  ADD COLUMN "search_vector" tsvector
+  GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', COALESCE("title", '')), 'A') ||
+    setweight(to_tsvector('simple', COALESCE("desc", '')), 'B')
+  ) STORED;
+
+CREATE INDEX "idx_products_search_vector" ON "products" USING GIN ("search_vector");
+
+COMMIT;
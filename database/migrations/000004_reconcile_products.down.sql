@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE "order_items" ALTER COLUMN "discount" TYPE INT USING ROUND("discount")::INT;
+
+ALTER TABLE "products" RENAME COLUMN "name" TO "title";
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE "products" RENAME COLUMN "title" TO "name";
+
+-- discounts are money like price, keep the order snapshot the same type
+ALTER TABLE "order_items" ALTER COLUMN "discount" TYPE FLOAT USING "discount"::FLOAT;
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+-- bumped on every write, GET /products/:productId returns it as the ETag
+ALTER TABLE "products" ADD COLUMN "version" INTEGER NOT NULL DEFAULT 1;
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP INDEX IF EXISTS "inventory_logs_product_order_idx";
+
+ALTER TABLE "inventory_logs" DROP COLUMN "seq";
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+-- "date" comes from NOW() for every log and is shared by all rows written in
+-- one transaction, "seq" keeps their insert order
+ALTER TABLE "inventory_logs" ADD COLUMN "seq" BIGSERIAL;
+
+CREATE INDEX "inventory_logs_product_order_idx" ON "inventory_logs" ("product_id", "date" DESC, "seq" DESC);
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP INDEX IF EXISTS "categories_title_key";
+
+COMMIT;
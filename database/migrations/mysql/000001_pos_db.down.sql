@@ -0,0 +1,15 @@
+DROP TABLE IF EXISTS `oauth`;
+DROP TABLE IF EXISTS `inventory_logs`;
+DROP TABLE IF EXISTS `payments`;
+DROP TABLE IF EXISTS `order_items`;
+DROP TABLE IF EXISTS `orders`;
+DROP TABLE IF EXISTS `customers`;
+DROP TABLE IF EXISTS `products`;
+DROP TABLE IF EXISTS `categories`;
+DROP TABLE IF EXISTS `users`;
+DROP TABLE IF EXISTS `user_roles`;
+
+DROP TABLE IF EXISTS `seq_user_id`;
+DROP TABLE IF EXISTS `seq_product_id`;
+DROP TABLE IF EXISTS `seq_customer_id`;
+DROP TABLE IF EXISTS `seq_order_id`;
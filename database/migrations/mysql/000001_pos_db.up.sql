@@ -0,0 +1,102 @@
+-- MySQL has no sequences, each seq_* table hands out the number behind the
+-- U/P/C/O prefixed ids through AUTO_INCREMENT.
+CREATE TABLE `seq_user_id` (`id` INT AUTO_INCREMENT PRIMARY KEY);
+CREATE TABLE `seq_product_id` (`id` INT AUTO_INCREMENT PRIMARY KEY);
+CREATE TABLE `seq_customer_id` (`id` INT AUTO_INCREMENT PRIMARY KEY);
+CREATE TABLE `seq_order_id` (`id` INT AUTO_INCREMENT PRIMARY KEY);
+
+CREATE TABLE `user_roles` (
+  `role_id` INT AUTO_INCREMENT PRIMARY KEY,
+  `title` VARCHAR(255) UNIQUE NOT NULL
+);
+
+CREATE TABLE `users` (
+  `user_id` VARCHAR(7) PRIMARY KEY,
+  `email` VARCHAR(255) UNIQUE NOT NULL,
+  `username` VARCHAR(255) UNIQUE NOT NULL,
+  `password` VARCHAR(255) NOT NULL,
+  `role_id` INT NOT NULL,
+  `created_at` DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+  `updated_at` DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+  FOREIGN KEY (`role_id`) REFERENCES `user_roles` (`role_id`) ON DELETE CASCADE
+);
+
+CREATE TABLE `categories` (
+  `category_id` INT AUTO_INCREMENT PRIMARY KEY,
+  `title` VARCHAR(255) NOT NULL,
+  `desc` VARCHAR(255)
+);
+
+CREATE TABLE `products` (
+  `product_id` VARCHAR(7) PRIMARY KEY,
+  `name` VARCHAR(255) NOT NULL,
+  `desc` VARCHAR(255),
+  `price` DOUBLE DEFAULT 0,
+  `discount` DOUBLE DEFAULT 0,
+  `stock` INT DEFAULT 0,
+  `category_id` INT NOT NULL,
+  `created_at` DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+  `updated_at` DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+  FULLTEXT KEY `idx_products_search` (`name`, `desc`),
+  FOREIGN KEY (`category_id`) REFERENCES `categories` (`category_id`) ON DELETE CASCADE
+);
+
+CREATE TABLE `customers` (
+  `customer_id` VARCHAR(7) PRIMARY KEY,
+  `first_name` VARCHAR(255) NOT NULL,
+  `last_name` VARCHAR(255) NOT NULL,
+  `phone` VARCHAR(255) NOT NULL UNIQUE,
+  `email` VARCHAR(255) NOT NULL UNIQUE,
+  `address` VARCHAR(255) NOT NULL,
+  `created_at` DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+  `updated_at` DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6)
+);
+
+CREATE TABLE `orders` (
+  `order_id` VARCHAR(7) PRIMARY KEY,
+  `customer_id` VARCHAR(7) NOT NULL,
+  `total_amount` DOUBLE NOT NULL,
+  `payment_method` ENUM('CASH', 'TRANSFER', 'ETC') NOT NULL,
+  `status` ENUM('WAITING', 'COMPLETED', 'CANCEL') NOT NULL,
+  `order_date` DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+  FOREIGN KEY (`customer_id`) REFERENCES `customers` (`customer_id`) ON DELETE CASCADE
+);
+
+CREATE TABLE `order_items` (
+  `order_item_id` CHAR(36) PRIMARY KEY DEFAULT (UUID()),
+  `order_id` VARCHAR(7) NOT NULL,
+  `product_id` VARCHAR(7) NOT NULL,
+  `quantity` INT NOT NULL DEFAULT 1,
+  `price` DOUBLE NOT NULL DEFAULT 0,
+  `discount` DOUBLE DEFAULT 0,
+  FOREIGN KEY (`order_id`) REFERENCES `orders` (`order_id`) ON DELETE CASCADE,
+  FOREIGN KEY (`product_id`) REFERENCES `products` (`product_id`) ON DELETE CASCADE
+);
+
+CREATE TABLE `payments` (
+  `payment_id` CHAR(36) PRIMARY KEY DEFAULT (UUID()),
+  `order_id` VARCHAR(7) NOT NULL,
+  `amount` DOUBLE NOT NULL DEFAULT 0,
+  `payment_method` ENUM('CASH', 'TRANSFER', 'ETC') NOT NULL,
+  `payment_date` DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+  FOREIGN KEY (`order_id`) REFERENCES `orders` (`order_id`) ON DELETE CASCADE
+);
+
+CREATE TABLE `inventory_logs` (
+  `inventory_log_id` CHAR(36) PRIMARY KEY DEFAULT (UUID()),
+  `product_id` VARCHAR(7) NOT NULL,
+  `change` VARCHAR(255) NOT NULL,
+  `description` VARCHAR(255),
+  `date` DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+  FOREIGN KEY (`product_id`) REFERENCES `products` (`product_id`) ON DELETE CASCADE
+);
+
+CREATE TABLE `oauth` (
+  `oauth_id` CHAR(36) PRIMARY KEY DEFAULT (UUID()),
+  `user_id` VARCHAR(7) NOT NULL,
+  `access_token` VARCHAR(1024) NOT NULL,
+  `refresh_token` VARCHAR(1024) NOT NULL,
+  `created_at` DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+  `updated_at` DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+  FOREIGN KEY (`user_id`) REFERENCES `users` (`user_id`) ON DELETE CASCADE
+);
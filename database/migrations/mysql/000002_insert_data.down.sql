@@ -0,0 +1,19 @@
+SET FOREIGN_KEY_CHECKS = 0;
+
+TRUNCATE TABLE `users`;
+TRUNCATE TABLE `user_roles`;
+TRUNCATE TABLE `products`;
+TRUNCATE TABLE `categories`;
+TRUNCATE TABLE `customers`;
+TRUNCATE TABLE `orders`;
+TRUNCATE TABLE `order_items`;
+TRUNCATE TABLE `payments`;
+TRUNCATE TABLE `inventory_logs`;
+TRUNCATE TABLE `oauth`;
+
+TRUNCATE TABLE `seq_user_id`;
+TRUNCATE TABLE `seq_product_id`;
+TRUNCATE TABLE `seq_customer_id`;
+TRUNCATE TABLE `seq_order_id`;
+
+SET FOREIGN_KEY_CHECKS = 1;
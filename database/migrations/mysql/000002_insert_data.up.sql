@@ -0,0 +1,27 @@
+START TRANSACTION;
+
+INSERT INTO `user_roles` (`title`) VALUES ('employee'), ('admin'), ('superadmin');
+
+INSERT INTO `seq_user_id` (`id`) VALUES (1), (2), (3);
+INSERT INTO `users` (`user_id`, `email`, `username`, `password`, `role_id`)
+VALUES
+  ('U000001', 'emp001@mail.com', 'employee001', '$2y$10$SvkeV0XlhJYx5HdYJM96reaU76WfZngkn2iWfdJG94Mdld9F.akVW', 1),
+  ('U000002', 'admin001@mail.com', 'admin001', '$2y$10$SvkeV0XlhJYx5HdYJM96reaU76WfZngkn2iWfdJG94Mdld9F.akVW', 2),
+  ('U000003', 'super@mail.com', 'superadmin001', '$2y$10$aROrDjTllIQYVmCFUEK7F.ubjQA7nzhEzOFeLntdSqK6OiyeuQb6m', 3);
+
+INSERT INTO `categories` (`title`, `desc`)
+VALUES
+  ('food & beverage', ''),
+  ('fashion', 'all fashion in the world'),
+  ('gadget', 'just a gadget');
+
+INSERT INTO `seq_product_id` (`id`) VALUES (1), (2), (3), (4), (5);
+INSERT INTO `products` (`product_id`, `name`, `desc`, `price`, `discount`, `stock`, `category_id`)
+VALUES
+    ('P000001', 'Coffee', 'Just a food & beverage product', 150, 30, 999, 1),
+    ('P000002', 'Steak', 'Just a food & beverage product', 200, 0, 100, 1),
+    ('P000003', 'Shirt', 'Just a fashion product', 590, 90, 20, 2),
+    ('P000004', 'Phone', 'Just a gadget product', 33400, 3400, 8, 3),
+    ('P000005', 'Computer', 'Just a gadget product', 49000, 500, 3, 3);
+
+COMMIT;
@@ -0,0 +1,3 @@
+DROP INDEX `inventory_logs_product_order_idx` ON `inventory_logs`;
+
+ALTER TABLE `inventory_logs` DROP COLUMN `seq`;
@@ -0,0 +1,3 @@
+ALTER TABLE `inventory_logs` ADD COLUMN `seq` BIGINT NOT NULL AUTO_INCREMENT UNIQUE;
+
+CREATE INDEX `inventory_logs_product_order_idx` ON `inventory_logs` (`product_id`, `date` DESC, `seq` DESC);
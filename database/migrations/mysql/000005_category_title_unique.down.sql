@@ -0,0 +1 @@
+DROP INDEX `categories_title_key` ON `categories`;
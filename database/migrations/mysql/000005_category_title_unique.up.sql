@@ -0,0 +1 @@
+CREATE UNIQUE INDEX `categories_title_key` ON `categories` (`title`);
@@ -0,0 +1,19 @@
+BEGIN;
+
+DROP TRIGGER IF EXISTS "products_fts_insert";
+DROP TRIGGER IF EXISTS "products_fts_delete";
+DROP TRIGGER IF EXISTS "products_fts_update";
+DROP TABLE IF EXISTS "products_fts";
+
+DROP TABLE IF EXISTS "oauth";
+DROP TABLE IF EXISTS "inventory_logs";
+DROP TABLE IF EXISTS "payments";
+DROP TABLE IF EXISTS "order_items";
+DROP TABLE IF EXISTS "orders";
+DROP TABLE IF EXISTS "customers";
+DROP TABLE IF EXISTS "products";
+DROP TABLE IF EXISTS "categories";
+DROP TABLE IF EXISTS "users";
+DROP TABLE IF EXISTS "user_roles";
+
+COMMIT;
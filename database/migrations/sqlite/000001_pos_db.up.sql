@@ -0,0 +1,130 @@
+BEGIN;
+
+-- SQLite has no sequences, the AUTOINCREMENT "seq" column backs the
+-- U/P/C/O prefixed ids and *_id is generated from it.
+-- uuid columns default to a random version 4 uuid in text form.
+
+CREATE TABLE "user_roles" (
+  "role_id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "title" TEXT UNIQUE NOT NULL
+);
+
+CREATE TABLE "users" (
+  "seq" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "user_id" TEXT GENERATED ALWAYS AS ('U' || printf('%06d', "seq")) STORED UNIQUE,
+  "email" TEXT UNIQUE NOT NULL,
+  "username" TEXT UNIQUE NOT NULL,
+  "password" TEXT NOT NULL,
+  "role_id" INTEGER NOT NULL REFERENCES "user_roles" ("role_id") ON DELETE CASCADE,
+  "created_at" TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  "updated_at" TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE "categories" (
+  "category_id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "title" TEXT NOT NULL,
+  "desc" TEXT
+);
+
+CREATE TABLE "products" (
+  "seq" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "product_id" TEXT GENERATED ALWAYS AS ('P' || printf('%06d', "seq")) STORED UNIQUE,
+  "name" TEXT NOT NULL,
+  "desc" TEXT,
+  "price" REAL DEFAULT 0,
+  "discount" REAL DEFAULT 0,
+  "stock" INTEGER DEFAULT 0,
+  "category_id" INTEGER NOT NULL REFERENCES "categories" ("category_id") ON DELETE CASCADE,
+  "created_at" TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  "updated_at" TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE "customers" (
+  "seq" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "customer_id" TEXT GENERATED ALWAYS AS ('C' || printf('%06d', "seq")) STORED UNIQUE,
+  "first_name" TEXT NOT NULL,
+  "last_name" TEXT NOT NULL,
+  "phone" TEXT NOT NULL UNIQUE,
+  "email" TEXT NOT NULL UNIQUE,
+  "address" TEXT NOT NULL,
+  "created_at" TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  "updated_at" TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE "orders" (
+  "seq" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "order_id" TEXT GENERATED ALWAYS AS ('O' || printf('%06d', "seq")) STORED UNIQUE,
+  "customer_id" TEXT NOT NULL REFERENCES "customers" ("customer_id") ON DELETE CASCADE,
+  "total_amount" REAL NOT NULL,
+  "payment_method" TEXT NOT NULL CHECK ("payment_method" IN ('CASH', 'TRANSFER', 'ETC')),
+  "status" TEXT NOT NULL CHECK ("status" IN ('WAITING', 'COMPLETED', 'CANCEL')),
+  "order_date" TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE "order_items" (
+  "order_item_id" TEXT PRIMARY KEY DEFAULT (lower(
+    hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' || substr(hex(randomblob(2)), 2) || '-' ||
+    substr('89ab', abs(random()) % 4 + 1, 1) || substr(hex(randomblob(2)), 2) || '-' || hex(randomblob(6))
+  )),
+  "order_id" TEXT NOT NULL REFERENCES "orders" ("order_id") ON DELETE CASCADE,
+  "product_id" TEXT NOT NULL REFERENCES "products" ("product_id") ON DELETE CASCADE,
+  "quantity" INTEGER NOT NULL DEFAULT 1,
+  "price" REAL NOT NULL DEFAULT 0,
+  "discount" REAL DEFAULT 0
+);
+
+CREATE TABLE "payments" (
+  "payment_id" TEXT PRIMARY KEY DEFAULT (lower(
+    hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' || substr(hex(randomblob(2)), 2) || '-' ||
+    substr('89ab', abs(random()) % 4 + 1, 1) || substr(hex(randomblob(2)), 2) || '-' || hex(randomblob(6))
+  )),
+  "order_id" TEXT NOT NULL REFERENCES "orders" ("order_id") ON DELETE CASCADE,
+  "amount" REAL NOT NULL DEFAULT 0,
+  "payment_method" TEXT NOT NULL CHECK ("payment_method" IN ('CASH', 'TRANSFER', 'ETC')),
+  "payment_date" TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE "inventory_logs" (
+  "inventory_log_id" TEXT PRIMARY KEY DEFAULT (lower(
+    hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' || substr(hex(randomblob(2)), 2) || '-' ||
+    substr('89ab', abs(random()) % 4 + 1, 1) || substr(hex(randomblob(2)), 2) || '-' || hex(randomblob(6))
+  )),
+  "product_id" TEXT NOT NULL REFERENCES "products" ("product_id") ON DELETE CASCADE,
+  "change" TEXT NOT NULL,
+  "description" TEXT,
+  "date" TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE "oauth" (
+  "oauth_id" TEXT PRIMARY KEY DEFAULT (lower(
+    hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' || substr(hex(randomblob(2)), 2) || '-' ||
+    substr('89ab', abs(random()) % 4 + 1, 1) || substr(hex(randomblob(2)), 2) || '-' || hex(randomblob(6))
+  )),
+  "user_id" TEXT NOT NULL REFERENCES "users" ("user_id") ON DELETE CASCADE,
+  "access_token" TEXT NOT NULL,
+  "refresh_token" TEXT NOT NULL,
+  "created_at" TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  "updated_at" TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- FTS5 index over products, kept in sync by the triggers below
+CREATE VIRTUAL TABLE "products_fts" USING fts5 (
+  "name", "desc",
+  content = 'products',
+  content_rowid = 'seq'
+);
+
+CREATE TRIGGER "products_fts_insert" AFTER INSERT ON "products" BEGIN
+  INSERT INTO "products_fts" ("rowid", "name", "desc") VALUES (new."seq", new."name", new."desc");
+END;
+
+CREATE TRIGGER "products_fts_delete" AFTER DELETE ON "products" BEGIN
+  INSERT INTO "products_fts" ("products_fts", "rowid", "name", "desc") VALUES ('delete', old."seq", old."name", old."desc");
+END;
+
+CREATE TRIGGER "products_fts_update" AFTER UPDATE ON "products" BEGIN
+  INSERT INTO "products_fts" ("products_fts", "rowid", "name", "desc") VALUES ('delete', old."seq", old."name", old."desc");
+  INSERT INTO "products_fts" ("rowid", "name", "desc") VALUES (new."seq", new."name", new."desc");
+END;
+
+COMMIT;
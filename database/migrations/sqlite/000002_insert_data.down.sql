@@ -0,0 +1,15 @@
+BEGIN;
+
+DELETE FROM "oauth";
+DELETE FROM "inventory_logs";
+DELETE FROM "payments";
+DELETE FROM "order_items";
+DELETE FROM "orders";
+DELETE FROM "customers";
+DELETE FROM "products";
+DELETE FROM "categories";
+DELETE FROM "users";
+DELETE FROM "user_roles";
+DELETE FROM "sqlite_sequence";
+
+COMMIT;
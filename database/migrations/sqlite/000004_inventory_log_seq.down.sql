@@ -0,0 +1,9 @@
+BEGIN;
+
+DROP INDEX IF EXISTS "inventory_logs_product_order_idx";
+
+DROP TRIGGER IF EXISTS "inventory_logs_seq";
+
+ALTER TABLE "inventory_logs" DROP COLUMN "seq";
+
+COMMIT;
@@ -0,0 +1,15 @@
+BEGIN;
+
+-- SQLite can't add an autoincrement column, "seq" copies the rowid instead
+ALTER TABLE "inventory_logs" ADD COLUMN "seq" INTEGER;
+
+UPDATE "inventory_logs" SET "seq" = "rowid";
+
+CREATE TRIGGER "inventory_logs_seq" AFTER INSERT ON "inventory_logs"
+BEGIN
+  UPDATE "inventory_logs" SET "seq" = NEW."rowid" WHERE "rowid" = NEW."rowid";
+END;
+
+CREATE INDEX "inventory_logs_product_order_idx" ON "inventory_logs" ("product_id", "date" DESC, "seq" DESC);
+
+COMMIT;
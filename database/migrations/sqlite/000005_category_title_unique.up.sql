@@ -0,0 +1,5 @@
+BEGIN;
+
+CREATE UNIQUE INDEX "categories_title_key" ON "categories" ("title");
+
+COMMIT;
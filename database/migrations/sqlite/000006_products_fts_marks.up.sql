@@ -0,0 +1,14 @@
+BEGIN;
+
+DROP TABLE "products_fts";
+
+CREATE VIRTUAL TABLE "products_fts" USING fts5 (
+  "name", "desc",
+  content = 'products',
+  content_rowid = 'seq',
+  tokenize = "unicode61 categories 'L* N* Co M*'"
+);
+
+INSERT INTO "products_fts" ("products_fts") VALUES ('rebuild');
+
+COMMIT;
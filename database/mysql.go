@@ -0,0 +1,60 @@
+package database
+
+import (
+	"fmt"
+
+	"github.com/codepnw/sales-api/config"
+	"github.com/codepnw/sales-api/pkg/logs"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// MysqlDesc quotes the desc column for the MySQL queries, desc is reserved
+// there and raw strings can't hold the backticks it needs.
+const MysqlDesc = "`desc`"
+
+var dbMysql *gorm.DB
+
+// NewMysqlConnect expects a go-sql-driver DSN with parseTime=true, for
+// example: user:pass@tcp(localhost:3306)/pos?parseTime=true
+func NewMysqlConnect(cfg config.IConfig) error {
+	connection, err := gorm.Open(mysql.Open(cfg.DB().DSN()), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		logs.Error(err)
+		return fmt.Errorf("failed connection database")
+	}
+
+	pool, err := connection.DB()
+	if err != nil {
+		logs.Error(err)
+		return fmt.Errorf("failed connection database")
+	}
+	configurePool(pool, cfg.DB())
+
+	dbMysql = connection
+	logs.Info("mysql database connected successfully")
+
+	return nil
+}
+
+func GetMysqlDB() *gorm.DB {
+	return dbMysql
+}
+
+// NextMysqlId emulates the PostgreSQL sequences behind ids like P000001, it
+// must run inside a transaction so LAST_INSERT_ID reads the same connection.
+func NextMysqlId(tx *gorm.DB, sequence, prefix string) (string, error) {
+	var id int64
+
+	if err := tx.Exec(fmt.Sprintf("INSERT INTO `%s` () VALUES ();", sequence)).Error; err != nil {
+		return "", err
+	}
+	if err := tx.Raw("SELECT LAST_INSERT_ID();").Scan(&id).Error; err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s%06d", prefix, id), nil
+}
@@ -17,6 +17,7 @@ func NewPostgresConnect(cfg config.IConfig) error {
 		logs.Error(err)
 		return fmt.Errorf("failed connection database")
 	}
+	configurePool(connection.DB, cfg.DB())
 
 	dbPostgres = connection
 	logs.Info("postgres database connected successfully")
@@ -0,0 +1,81 @@
+package database
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// information_schema data_type values used by the migrations
+const (
+	TypeVarchar   string = "character varying"
+	TypeInt       string = "integer"
+	TypeBigInt    string = "bigint"
+	TypeFloat     string = "double precision"
+	TypeTimestamp string = "timestamp without time zone"
+	TypeUUID      string = "uuid"
+	TypeEnum      string = "USER-DEFINED"
+	TypeTsvector  string = "tsvector"
+)
+
+// TableSchema lists the columns a repository reads or writes, keyed by name
+// with the data_type information_schema reports for them.
+type TableSchema struct {
+	Table   string
+	Columns map[string]string
+}
+
+type column struct {
+	Name     string `db:"column_name"`
+	DataType string `db:"data_type"`
+}
+
+// CheckSchema compares every schema against information_schema and returns
+// one error listing all missing columns and type mismatches.
+func CheckSchema(db *sqlx.DB, schemas ...TableSchema) error {
+	problems := make([]string, 0)
+
+	for _, schema := range schemas {
+		columns := make([]*column, 0)
+
+		query := `
+			SELECT "column_name", "data_type"
+			FROM "information_schema"."columns"
+			WHERE "table_schema" = current_schema()
+			AND "table_name" = $1;
+		`
+		if err := db.Select(&columns, query, schema.Table); err != nil {
+			return err
+		}
+
+		actual := make(map[string]string, len(columns))
+		for _, c := range columns {
+			actual[c.Name] = c.DataType
+		}
+
+		names := make([]string, 0, len(schema.Columns))
+		for name := range schema.Columns {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			want := schema.Columns[name]
+			got, ok := actual[name]
+
+			switch {
+			case !ok:
+				problems = append(problems, fmt.Sprintf("%s.%s is missing", schema.Table, name))
+			case got != want:
+				problems = append(problems, fmt.Sprintf("%s.%s is %s, want %s", schema.Table, name, got, want))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("database schema mismatch: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
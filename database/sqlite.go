@@ -0,0 +1,58 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/codepnw/sales-api/config"
+	"github.com/codepnw/sales-api/pkg/logs"
+	"github.com/jmoiron/sqlx"
+	_ "modernc.org/sqlite"
+)
+
+var dbSqlite *sqlx.DB
+
+// NewSqliteConnect opens a SQLite file through the pure Go driver, e.g.
+// db.dsn: "file:sales.db" or "file::memory:". SQLite has no row locks, so
+// the pool is kept to one connection whatever db.max_connections says and
+// every transaction is serialized. That connection is never recycled, an
+// in-memory database is gone once it closes.
+func NewSqliteConnect(cfg config.IConfig) error {
+	dsn := cfg.DB().DSN()
+	if !strings.Contains(dsn, "foreign_keys") {
+		sep := "?"
+		if strings.Contains(dsn, "?") {
+			sep = "&"
+		}
+		dsn += sep + "_pragma=foreign_keys(1)"
+	}
+
+	connection, err := sqlx.Connect(DriverSqlite, dsn)
+	if err != nil {
+		logs.Error(err)
+		return fmt.Errorf("failed connection database")
+	}
+	configurePool(connection.DB, cfg.DB())
+	connection.SetMaxOpenConns(1)
+	connection.SetMaxIdleConns(1)
+	connection.SetConnMaxLifetime(0)
+	connection.SetConnMaxIdleTime(0)
+
+	dbSqlite = connection
+	logs.Info("sqlite database connected successfully")
+
+	return nil
+}
+
+func GetSqliteDB() *sqlx.DB {
+	return dbSqlite
+}
+
+// ForUpdate returns the row lock clause for the driver behind db, SQLite
+// doesn't support it and relies on its single connection instead.
+func ForUpdate(db interface{ DriverName() string }) string {
+	if db.DriverName() == DriverSqlite {
+		return ""
+	}
+	return "FOR UPDATE"
+}
@@ -1,10 +1,20 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
 	"github.com/codepnw/sales-api/config"
 	"github.com/codepnw/sales-api/database"
+	"github.com/codepnw/sales-api/pkg/logs"
 	"github.com/codepnw/sales-api/routes"
 	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
 )
 
 const (
@@ -16,12 +26,92 @@ func main() {
 	config.InitTimezone()
 	cfg := config.InitConfig(configPath, configFile)
 
-	if err := database.NewPostgresConnect(cfg); err != nil {
+	if _, ok := migrateArgs(); ok {
+		if err := checkMigrateDriver(cfg.DB().Driver()); err != nil {
+			logs.Error(err)
+			os.Exit(1)
+		}
+	}
+
+	if err := database.Connect(cfg); err != nil {
 		panic(err)
 	}
 
-	app := gin.Default()
-	routes.Setup(app, cfg.App().Version())
+	// the migration runner covers postgres and sqlite, MySQL uses
+	// database/migrations/mysql with the golang-migrate CLI
+	switch cfg.DB().Driver() {
+	case database.DriverPostgres:
+		prepareDatabase(cfg, database.GetPostgresDB())
+	case database.DriverSqlite:
+		prepareDatabase(cfg, database.GetSqliteDB())
+	}
+
+	// routes.Setup adds the request id, access log and recovery middlewares
+	app := gin.New()
+	routes.Setup(app, cfg)
+
+	server := &http.Server{
+		Addr:    cfg.App().Port(),
+		Handler: app,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logs.Error(err)
+			stop()
+		}
+	}()
+	logs.Info("server listening", zap.String("addr", cfg.App().Port()))
 
-	app.Run(cfg.App().Port())
+	<-ctx.Done()
+	stop()
+	logs.Info("shutting down, waiting for in-flight requests")
+
+	// stop accepting connections and let the running requests finish within
+	// app.shutdown_timeout, then close the pool they were using
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.App().ShutdownTimeout())
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		logs.Error(err)
+	}
+	if err := database.Close(); err != nil {
+		logs.Error(err)
+	}
+	logs.Info("server stopped")
+	logs.Sync()
+}
+
+func prepareDatabase(cfg config.IConfig, db *sqlx.DB) {
+	if args, ok := migrateArgs(); ok {
+		if err := runMigrate(db, args); err != nil {
+			logs.Error(err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	if cfg.DB().AutoMigrate() {
+		migrator, err := database.NewMigrator(db)
+		if err != nil {
+			panic(err)
+		}
+		if err := migrator.Up(context.Background()); err != nil {
+			logs.Error(err)
+			panic(err)
+		}
+	}
+
+	if cfg.DB().Driver() != database.DriverPostgres {
+		return
+	}
+
+	// refuse to boot when the migrations and the repositories disagree
+	if err := database.CheckSchema(db, routes.Schemas()...); err != nil {
+		logs.Error(err)
+		panic(err)
+	}
 }
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/codepnw/sales-api/database"
+	"github.com/jmoiron/sqlx"
+)
+
+const migrateUsage = `usage: migrate <command>
+  up          apply all pending migrations
+  down        roll back the last applied migration
+  to N        migrate up or down to version N, 0 rolls back everything
+  force N     set version N without running migrations, clears dirty
+  status      print the current version and the known migrations`
+
+// checkMigrateDriver stops "migrate ..." from booting the server under a
+// driver the runner doesn't handle, only postgres and sqlite embed their
+// migrations.
+func checkMigrateDriver(driver string) error {
+	switch driver {
+	case database.DriverPostgres, database.DriverSqlite:
+		return nil
+	case database.DriverMysql:
+		return errors.New("the migrate command doesn't support the mysql driver, apply database/migrations/mysql with the golang-migrate CLI")
+	}
+	return fmt.Errorf("the migrate command doesn't support the %s driver", driver)
+}
+
+// migrateArgs returns the arguments after "migrate" when the binary was
+// started as the migrate command.
+func migrateArgs() ([]string, bool) {
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		return os.Args[2:], true
+	}
+	return nil, false
+}
+
+// runMigrate handles "migrate ..." arguments, for example: go run . migrate up
+func runMigrate(db *sqlx.DB, args []string) error {
+	migrator, err := database.NewMigrator(db)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	switch args[0] {
+	case "up":
+		return migrator.Up(ctx)
+	case "down":
+		return migrator.Down(ctx)
+	case "to", "force":
+		if len(args) < 2 {
+			return errors.New(migrateUsage)
+		}
+		version, err := strconv.ParseUint(args[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid version %q", args[1])
+		}
+		if args[0] == "force" {
+			return migrator.Force(ctx, uint(version))
+		}
+		return migrator.To(ctx, uint(version))
+	case "status":
+		status, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("version: %d, dirty: %t\n", status.Version, status.Dirty)
+		for _, m := range status.Migrations {
+			state := "pending"
+			if m.Version <= status.Version {
+				state = "applied"
+			}
+			fmt.Printf("  %06d_%s\t%s\n", m.Version, m.Name, state)
+		}
+		return nil
+	}
+
+	return errors.New(migrateUsage)
+}
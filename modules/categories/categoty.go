@@ -5,3 +5,13 @@ type Category struct {
 	Title      string `db:"title" json:"title"`
 	Desc       string `db:"desc" json:"desc"`
 }
+
+type CategoryRequest struct {
+	Title string `json:"title" binding:"required,max=255"`
+	Desc  string `json:"desc" binding:"max=255"`
+}
+
+// Request returns the fields of c a client may change.
+func (c *Category) Request() CategoryRequest {
+	return CategoryRequest{Title: c.Title, Desc: c.Desc}
+}
@@ -22,32 +22,25 @@ func NewCategoryHandler(service catservices.ICategoryService) *categoryHandler {
 type categoryErr string
 
 const (
-	createError categoryErr = "category-001"
-	getOneError categoryErr = "category-002"
-	getAllError categoryErr = "category-003"
-	updateError categoryErr = "category-004"
-	deleteError categoryErr = "category-005"
+	createError  categoryErr = "category-001"
+	getOneError  categoryErr = "category-002"
+	getAllError  categoryErr = "category-003"
+	updateError  categoryErr = "category-004"
+	deleteError  categoryErr = "category-005"
+	replaceError categoryErr = "category-006"
 )
 
 func (h *categoryHandler) CreateCategory(c *gin.Context) {
-	request := categories.Category{}
+	request := categories.CategoryRequest{}
 
 	if err := c.ShouldBindJSON(&request); err != nil {
-		utils.NewResponse(c).Error(
-			http.StatusBadRequest,
-			string(createError),
-			err.Error(),
-		)
+		utils.NewResponse(c).BindError(string(createError), err)
 		return
 	}
 
-	category, err := h.service.CreateCategory(&request)
+	category, err := h.service.CreateCategory(c.Request.Context(), &request)
 	if err != nil {
-		utils.NewResponse(c).Error(
-			http.StatusInternalServerError,
-			string(createError),
-			err.Error(),
-		)
+		utils.NewResponse(c).Fail(string(createError), err)
 		return
 	}
 
@@ -58,13 +51,9 @@ func (h *categoryHandler) GetOneCategory(c *gin.Context) {
 	idStr := strings.Trim(c.Param("categoryId"), " ")
 	id, _ := strconv.Atoi(idStr)
 
-	category, err := h.service.GetOneCategory(id)
+	category, err := h.service.GetOneCategory(c.Request.Context(), id)
 	if err != nil {
-		utils.NewResponse(c).Error(
-			http.StatusInternalServerError,
-			string(getOneError),
-			err.Error(),
-		)
+		utils.NewResponse(c).Fail(string(getOneError), err)
 		return
 	}
 
@@ -72,13 +61,9 @@ func (h *categoryHandler) GetOneCategory(c *gin.Context) {
 }
 
 func (h *categoryHandler) GetAllCategory(c *gin.Context) {
-	categories, err := h.service.GetAllCategories()
+	categories, err := h.service.GetAllCategories(c.Request.Context())
 	if err != nil {
-		utils.NewResponse(c).Error(
-			http.StatusInternalServerError,
-			string(getAllError),
-			err.Error(),
-		)
+		utils.NewResponse(c).Fail(string(getAllError), err)
 		return
 	}
 
@@ -88,24 +73,41 @@ func (h *categoryHandler) GetAllCategory(c *gin.Context) {
 func (h *categoryHandler) UpdateCategory(c *gin.Context) {
 	idStr := strings.Trim(c.Param("categoryId"), " ")
 	id, _ := strconv.Atoi(idStr)
-	request := categories.Category{}
+
+	current, err := h.service.GetOneCategory(c.Request.Context(), id)
+	if err != nil {
+		utils.NewResponse(c).Fail(string(updateError), err)
+		return
+	}
+
+	request := current.Request()
+	if err := utils.ShouldBindMergePatch(c, &request); err != nil {
+		utils.NewResponse(c).BindError(string(updateError), err)
+		return
+	}
+
+	category, err := h.service.UpdateCategory(c.Request.Context(), id, &request)
+	if err != nil {
+		utils.NewResponse(c).Fail(string(updateError), err)
+		return
+	}
+
+	utils.NewResponse(c).Success(http.StatusOK, category)
+}
+
+func (h *categoryHandler) ReplaceCategory(c *gin.Context) {
+	idStr := strings.Trim(c.Param("categoryId"), " ")
+	id, _ := strconv.Atoi(idStr)
+	request := categories.CategoryRequest{}
 
 	if err := c.ShouldBindJSON(&request); err != nil {
-		utils.NewResponse(c).Error(
-			http.StatusBadRequest,
-			string(updateError),
-			err.Error(),
-		)
+		utils.NewResponse(c).BindError(string(replaceError), err)
 		return
 	}
 
-	category, err := h.service.UpdateCategory(id, &request)
+	category, err := h.service.UpdateCategory(c.Request.Context(), id, &request)
 	if err != nil {
-		utils.NewResponse(c).Error(
-			http.StatusInternalServerError,
-			string(updateError),
-			err.Error(),
-		)
+		utils.NewResponse(c).Fail(string(replaceError), err)
 		return
 	}
 
@@ -116,12 +118,8 @@ func (h *categoryHandler) DeleteCategory(c *gin.Context) {
 	idStr := strings.Trim(c.Param("categoryId"), " ")
 	id, _ := strconv.Atoi(idStr)
 
-	if err := h.service.DeleteCategory(id); err != nil {
-		utils.NewResponse(c).Error(
-			http.StatusInternalServerError,
-			string(deleteError),
-			err.Error(),
-		)
+	if err := h.service.DeleteCategory(c.Request.Context(), id); err != nil {
+		utils.NewResponse(c).Fail(string(deleteError), err)
 		return
 	}
 
@@ -2,18 +2,31 @@ package catrepositories
 
 import (
 	"context"
-	"time"
+	"database/sql"
 
+	"github.com/codepnw/sales-api/database"
 	"github.com/codepnw/sales-api/modules/categories"
+	"github.com/codepnw/sales-api/pkg/errs"
 	"github.com/jmoiron/sqlx"
 )
 
+var Schemas = []database.TableSchema{
+	{
+		Table: "categories",
+		Columns: map[string]string{
+			"category_id": database.TypeInt,
+			"title":       database.TypeVarchar,
+			"desc":        database.TypeVarchar,
+		},
+	},
+}
+
 type ICategoryRepo interface {
-	CreateCategory(category *categories.Category) (*categories.Category, error)
-	GetOneCategory(categoryId int) (*categories.Category, error)
-	GetAllCategories() ([]*categories.Category, error)
-	UpdateCategory(category *categories.Category) (*categories.Category, error)
-	DeleteCategory(categoryId int) error
+	CreateCategory(ctx context.Context, category *categories.Category) (*categories.Category, error)
+	GetOneCategory(ctx context.Context, categoryId int) (*categories.Category, error)
+	GetAllCategories(ctx context.Context) ([]*categories.Category, error)
+	UpdateCategory(ctx context.Context, category *categories.Category) (*categories.Category, error)
+	DeleteCategory(ctx context.Context, categoryId int) error
 }
 
 type categoryRepo struct {
@@ -24,8 +37,8 @@ func NewCategoryRepository(db *sqlx.DB) ICategoryRepo {
 	return &categoryRepo{db: db}
 }
 
-func (r *categoryRepo) CreateCategory(category *categories.Category) (*categories.Category, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+func (r *categoryRepo) CreateCategory(ctx context.Context, category *categories.Category) (*categories.Category, error) {
+	ctx, cancel := database.WithTimeout(ctx)
 	defer cancel()
 
 	query := `
@@ -35,13 +48,19 @@ func (r *categoryRepo) CreateCategory(category *categories.Category) (*categorie
 	`
 	err := r.db.QueryRowContext(ctx, query, category.Title, category.Desc).Scan(&category.CategoryId)
 	if err != nil {
+		if database.IsUniqueViolation(err) {
+			return nil, errs.Conflict("category %q already exists", category.Title)
+		}
 		return nil, err
 	}
 
 	return category, nil
 }
 
-func (r *categoryRepo) GetOneCategory(categoryId int) (*categories.Category, error) {
+func (r *categoryRepo) GetOneCategory(ctx context.Context, categoryId int) (*categories.Category, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+
 	category := categories.Category{}
 
 	query := `
@@ -49,19 +68,25 @@ func (r *categoryRepo) GetOneCategory(categoryId int) (*categories.Category, err
 		WHERE "category_id" = $1
 		LIMIT 1;
 	`
-	err := r.db.Get(&category, query, categoryId)
+	err := r.db.GetContext(ctx, &category, query, categoryId)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.NotFound("category %d not found", categoryId)
+		}
 		return nil, err
 	}
 
 	return &category, nil
 }
 
-func (r *categoryRepo) GetAllCategories() ([]*categories.Category, error) {
+func (r *categoryRepo) GetAllCategories(ctx context.Context) ([]*categories.Category, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+
 	categories := []*categories.Category{}
 
 	query := `SELECT * FROM "categories";`
-	err := r.db.Select(&categories, query)
+	err := r.db.SelectContext(ctx, &categories, query)
 	if err != nil {
 		return nil, err
 	}
@@ -69,20 +94,26 @@ func (r *categoryRepo) GetAllCategories() ([]*categories.Category, error) {
 	return categories, nil
 }
 
-func (r *categoryRepo) UpdateCategory(category *categories.Category) (*categories.Category, error) {
+func (r *categoryRepo) UpdateCategory(ctx context.Context, category *categories.Category) (*categories.Category, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+
 	query := `
 		UPDATE "categories"
 		SET
-			"title" = COALESCE(NULLIF($1, ''), "title"),
-			"desc" = COALESCE(NULLIF($2, ''), "desc")
+			"title" = $1,
+			"desc" = $2
 		WHERE "category_id" = $3;
 	`
-	_, err := r.db.ExecContext(context.Background(), query, category.Title, category.Desc, category.CategoryId)
+	_, err := r.db.ExecContext(ctx, query, category.Title, category.Desc, category.CategoryId)
 	if err != nil {
+		if database.IsUniqueViolation(err) {
+			return nil, errs.Conflict("category %q already exists", category.Title)
+		}
 		return nil, err
 	}
 
-	c, err := r.GetOneCategory(category.CategoryId)
+	c, err := r.GetOneCategory(ctx, category.CategoryId)
 	if err != nil {
 		return nil, err
 	}
@@ -90,17 +121,23 @@ func (r *categoryRepo) UpdateCategory(category *categories.Category) (*categorie
 	return c, nil
 }
 
-func (r *categoryRepo) DeleteCategory(categoryId int) error {
+func (r *categoryRepo) DeleteCategory(ctx context.Context, categoryId int) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+
 	query := `DELETE FROM "categories" WHERE "category_id" = $1;`
 
-	result, err := r.db.ExecContext(context.Background(), query, categoryId)
+	result, err := r.db.ExecContext(ctx, query, categoryId)
 	if err != nil {
 		return err
 	}
 
-	_, err = result.RowsAffected()
+	rows, err := result.RowsAffected()
 	if err != nil {
 		return err
 	}
+	if rows == 0 {
+		return errs.NotFound("category %d not found", categoryId)
+	}
 	return nil
 }
@@ -0,0 +1,121 @@
+package catrepositories
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	"github.com/codepnw/sales-api/database"
+	"github.com/codepnw/sales-api/modules/categories"
+	"github.com/codepnw/sales-api/modules/products"
+	"github.com/codepnw/sales-api/pkg/errs"
+)
+
+type categoryMemoryRepo struct {
+	db *database.MemoryDB
+}
+
+func NewCategoryMemoryRepository(db *database.MemoryDB) ICategoryRepo {
+	return &categoryMemoryRepo{db: db}
+}
+
+func (r *categoryMemoryRepo) CreateCategory(ctx context.Context, category *categories.Category) (*categories.Category, error) {
+	err := r.db.Update(func(tx *database.MemoryTx) error {
+		if titleTaken(tx, category.Title, 0) {
+			return errs.Conflict("category %q already exists", category.Title)
+		}
+
+		category.CategoryId = tx.NextId("categories")
+		tx.Put("categories", strconv.Itoa(category.CategoryId), *category)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+func (r *categoryMemoryRepo) GetOneCategory(ctx context.Context, categoryId int) (*categories.Category, error) {
+	category := categories.Category{}
+
+	err := r.db.View(func(tx *database.MemoryTx) error {
+		row, ok := tx.Get("categories", strconv.Itoa(categoryId))
+		if !ok {
+			return errs.NotFound("category %d not found", categoryId)
+		}
+		category = row.(categories.Category)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &category, nil
+}
+
+func (r *categoryMemoryRepo) GetAllCategories(ctx context.Context) ([]*categories.Category, error) {
+	cats := make([]*categories.Category, 0)
+
+	r.db.View(func(tx *database.MemoryTx) error {
+		for _, row := range tx.Rows("categories") {
+			category := row.(categories.Category)
+			cats = append(cats, &category)
+		}
+		return nil
+	})
+	sort.Slice(cats, func(i, j int) bool { return cats[i].CategoryId < cats[j].CategoryId })
+
+	return cats, nil
+}
+
+func (r *categoryMemoryRepo) UpdateCategory(ctx context.Context, category *categories.Category) (*categories.Category, error) {
+	err := r.db.Update(func(tx *database.MemoryTx) error {
+		key := strconv.Itoa(category.CategoryId)
+
+		if _, ok := tx.Get("categories", key); !ok {
+			return errs.NotFound("category %d not found", category.CategoryId)
+		}
+
+		if titleTaken(tx, category.Title, category.CategoryId) {
+			return errs.Conflict("category %q already exists", category.Title)
+		}
+		tx.Put("categories", key, *category)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+// DeleteCategory also removes the category's products, like the
+// ON DELETE CASCADE on products.category_id.
+func (r *categoryMemoryRepo) DeleteCategory(ctx context.Context, categoryId int) error {
+	return r.db.Update(func(tx *database.MemoryTx) error {
+		if !tx.Delete("categories", strconv.Itoa(categoryId)) {
+			return errs.NotFound("category %d not found", categoryId)
+		}
+
+		for _, row := range tx.Rows("products") {
+			product := row.(products.Product)
+			if product.CategoryID == uint(categoryId) {
+				tx.Delete("products", product.ProductID)
+			}
+		}
+		return nil
+	})
+}
+
+// titleTaken mirrors the UNIQUE constraint on categories.title, exceptId is
+// the category being updated.
+func titleTaken(tx *database.MemoryTx, title string, exceptId int) bool {
+	for _, row := range tx.Rows("categories") {
+		category := row.(categories.Category)
+		if category.Title == title && category.CategoryId != exceptId {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+package catrepositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/codepnw/sales-api/database"
+	"github.com/codepnw/sales-api/modules/categories"
+	"github.com/codepnw/sales-api/pkg/errs"
+	"gorm.io/gorm"
+)
+
+type categoryMysqlRepo struct {
+	db *gorm.DB
+}
+
+func NewCategoryMysqlRepository(db *gorm.DB) ICategoryRepo {
+	return &categoryMysqlRepo{db: db}
+}
+
+func (r *categoryMysqlRepo) CreateCategory(ctx context.Context, category *categories.Category) (*categories.Category, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		query := fmt.Sprintf("INSERT INTO categories (title, %s) VALUES (?, ?);", database.MysqlDesc)
+		if err := tx.Exec(query, category.Title, category.Desc).Error; err != nil {
+			return err
+		}
+		return tx.Raw("SELECT LAST_INSERT_ID();").Scan(&category.CategoryId).Error
+	})
+
+	if err != nil {
+		if database.IsUniqueViolation(err) {
+			return nil, errs.Conflict("category %q already exists", category.Title)
+		}
+		return nil, err
+	}
+
+	return category, nil
+}
+
+func (r *categoryMysqlRepo) GetOneCategory(ctx context.Context, categoryId int) (*categories.Category, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+
+	category := categories.Category{}
+
+	query := fmt.Sprintf(`
+		SELECT category_id, title, %s
+		FROM categories
+		WHERE category_id = ?
+		LIMIT 1;
+	`, database.MysqlDesc)
+	result := r.db.WithContext(ctx).Raw(query, categoryId).Scan(&category)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, errs.NotFound("category %d not found", categoryId)
+	}
+
+	return &category, nil
+}
+
+func (r *categoryMysqlRepo) GetAllCategories(ctx context.Context) ([]*categories.Category, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+
+	categories := []*categories.Category{}
+
+	query := fmt.Sprintf("SELECT category_id, title, %s FROM categories;", database.MysqlDesc)
+	if err := r.db.WithContext(ctx).Raw(query).Scan(&categories).Error; err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+func (r *categoryMysqlRepo) UpdateCategory(ctx context.Context, category *categories.Category) (*categories.Category, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+
+	query := fmt.Sprintf(`
+		UPDATE categories
+		SET
+			title = ?,
+			%s = ?
+		WHERE category_id = ?;
+	`, database.MysqlDesc)
+	err := r.db.WithContext(ctx).Exec(query, category.Title, category.Desc, category.CategoryId).Error
+	if err != nil {
+		if database.IsUniqueViolation(err) {
+			return nil, errs.Conflict("category %q already exists", category.Title)
+		}
+		return nil, err
+	}
+
+	c, err := r.GetOneCategory(ctx, category.CategoryId)
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (r *categoryMysqlRepo) DeleteCategory(ctx context.Context, categoryId int) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).Exec("DELETE FROM categories WHERE category_id = ?;", categoryId)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.NotFound("category %d not found", categoryId)
+	}
+
+	return nil
+}
@@ -1,20 +1,21 @@
 package catservices
 
 import (
-	"database/sql"
+	"context"
 	"fmt"
 
 	"github.com/codepnw/sales-api/modules/categories"
 	catrepositories "github.com/codepnw/sales-api/modules/categories/repositories"
+	"github.com/codepnw/sales-api/pkg/errs"
 	"github.com/codepnw/sales-api/pkg/logs"
 )
 
 type ICategoryService interface {
-	CreateCategory(request *categories.Category) (*categories.Category, error)
-	GetOneCategory(categoryId int) (*categories.Category, error)
-	GetAllCategories() ([]*categories.Category, error)
-	UpdateCategory(categoryId int, category *categories.Category) (*categories.Category, error)
-	DeleteCategory(categoryId int) error
+	CreateCategory(ctx context.Context, request *categories.CategoryRequest) (*categories.Category, error)
+	GetOneCategory(ctx context.Context, categoryId int) (*categories.Category, error)
+	GetAllCategories(ctx context.Context) ([]*categories.Category, error)
+	UpdateCategory(ctx context.Context, categoryId int, category *categories.CategoryRequest) (*categories.Category, error)
+	DeleteCategory(ctx context.Context, categoryId int) error
 }
 
 type categoryService struct {
@@ -25,61 +26,70 @@ func NewCategoryService(repo catrepositories.ICategoryRepo) ICategoryService {
 	return &categoryService{repo: repo}
 }
 
-func (s *categoryService) CreateCategory(request *categories.Category) (*categories.Category, error) {
+func (s *categoryService) CreateCategory(ctx context.Context, request *categories.CategoryRequest) (*categories.Category, error) {
 	category := categories.Category{
 		Title: request.Title,
 		Desc:  request.Desc,
 	}
 
-	result, err := s.repo.CreateCategory(&category)
+	result, err := s.repo.CreateCategory(ctx, &category)
 	if err != nil {
-		logs.Error(err)
-		return nil, err
+		logs.ErrorContext(ctx, err)
+		if errs.Known(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed create category")
 	}
 
 	return result, nil
 }
 
-func (s *categoryService) GetOneCategory(categoryId int) (*categories.Category, error) {
-	result, err := s.repo.GetOneCategory(categoryId)
+func (s *categoryService) GetOneCategory(ctx context.Context, categoryId int) (*categories.Category, error) {
+	result, err := s.repo.GetOneCategory(ctx, categoryId)
 	if err != nil {
-		logs.Error(err)
-		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("category id not found")
+		logs.ErrorContext(ctx, err)
+		if errs.Known(err) {
+			return nil, err
 		}
 		return nil, fmt.Errorf("failed get category")
 	}
 	return result, nil
 }
 
-func (s *categoryService) GetAllCategories() ([]*categories.Category, error) {
-	result, err := s.repo.GetAllCategories()
+func (s *categoryService) GetAllCategories(ctx context.Context) ([]*categories.Category, error) {
+	result, err := s.repo.GetAllCategories(ctx)
 	if err != nil {
-		logs.Error(err)
+		logs.ErrorContext(ctx, err)
 		return nil, fmt.Errorf("failed get category")
 	}
 	return result, nil
 }
 
-func (s *categoryService) UpdateCategory(categoryId int, category *categories.Category) (*categories.Category, error) {
+func (s *categoryService) UpdateCategory(ctx context.Context, categoryId int, category *categories.CategoryRequest) (*categories.Category, error) {
 	request := categories.Category{
 		CategoryId: categoryId,
 		Title:      category.Title,
 		Desc:       category.Desc,
 	}
 
-	result, err := s.repo.UpdateCategory(&request)
+	result, err := s.repo.UpdateCategory(ctx, &request)
 	if err != nil {
-		logs.Error(err)
+		logs.ErrorContext(ctx, err)
+		if errs.Known(err) {
+			return nil, err
+		}
 		return nil, fmt.Errorf("failed update category")
 	}
 
 	return result, nil
 }
 
-func (s *categoryService) DeleteCategory(categoryId int) error {
-	if err := s.repo.DeleteCategory(categoryId); err != nil {
-		logs.Error(err)
+func (s *categoryService) DeleteCategory(ctx context.Context, categoryId int) error {
+	if err := s.repo.DeleteCategory(ctx, categoryId); err != nil {
+		logs.ErrorContext(ctx, err)
+		if errs.Known(err) {
+			return err
+		}
 		return fmt.Errorf("failed delete category")
 	}
 	return nil
@@ -0,0 +1,40 @@
+package customers
+
+import (
+	"time"
+
+	"github.com/codepnw/sales-api/pkg/errs"
+)
+
+var ErrCustomerExists = errs.Conflict("phone or email already exists")
+
+type Customer struct {
+	CustomerId string    `db:"customer_id" json:"customerId"`
+	FirstName  string    `db:"first_name" json:"firstName"`
+	LastName   string    `db:"last_name" json:"lastName"`
+	Phone      string    `db:"phone" json:"phone"`
+	Email      string    `db:"email" json:"email"`
+	Address    string    `db:"address" json:"address"`
+	CreatedAt  time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt  time.Time `db:"updated_at" json:"updatedAt"`
+}
+
+// Request returns the fields of c a client may change, PATCH merges its
+// body into them and writes every field.
+func (c *Customer) Request() CustomerRequest {
+	return CustomerRequest{
+		FirstName: c.FirstName,
+		LastName:  c.LastName,
+		Phone:     c.Phone,
+		Email:     c.Email,
+		Address:   c.Address,
+	}
+}
+
+type CustomerRequest struct {
+	FirstName string `json:"firstName" form:"first_name" binding:"required,max=255"`
+	LastName  string `json:"lastName" form:"last_name" binding:"required,max=255"`
+	Phone     string `json:"phone" form:"phone" binding:"required,max=255"`
+	Email     string `json:"email" form:"email" binding:"required,email,max=255"`
+	Address   string `json:"address" form:"address" binding:"required,max=255"`
+}
@@ -0,0 +1,127 @@
+package custhandlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/codepnw/sales-api/modules/customers"
+	custservices "github.com/codepnw/sales-api/modules/customers/services"
+	"github.com/codepnw/sales-api/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type customerHandler struct {
+	service custservices.ICustomerService
+}
+
+func NewCustomerHandler(service custservices.ICustomerService) *customerHandler {
+	return &customerHandler{service: service}
+}
+
+type customerErr string
+
+const (
+	createError     customerErr = "customers-001"
+	getOneError     customerErr = "customers-002"
+	getAllError     customerErr = "customers-003"
+	updateError     customerErr = "customers-004"
+	deleteError     customerErr = "customers-005"
+	getByPhoneError customerErr = "customers-006"
+	getByEmailError customerErr = "customers-007"
+)
+
+func (h *customerHandler) CreateCustomer(c *gin.Context) {
+	request := customers.CustomerRequest{}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.NewResponse(c).BindError(string(createError), err)
+		return
+	}
+
+	customer, err := h.service.CreateCustomer(c.Request.Context(), &request)
+	if err != nil {
+		utils.NewResponse(c).Fail(string(createError), err)
+		return
+	}
+
+	utils.NewResponse(c).Success(http.StatusCreated, customer)
+}
+
+func (h *customerHandler) GetCustomers(c *gin.Context) {
+	customers, err := h.service.GetCustomers(c.Request.Context())
+	if err != nil {
+		utils.NewResponse(c).Fail(string(getAllError), err)
+		return
+	}
+
+	utils.NewResponse(c).Success(http.StatusOK, customers)
+}
+
+func (h *customerHandler) GetCustomer(c *gin.Context) {
+	id := strings.Trim(c.Param("customerId"), " ")
+
+	customer, err := h.service.GetCustomer(c.Request.Context(), id)
+	if err != nil {
+		utils.NewResponse(c).Fail(string(getOneError), err)
+		return
+	}
+
+	utils.NewResponse(c).Success(http.StatusOK, customer)
+}
+
+func (h *customerHandler) GetCustomerByPhone(c *gin.Context) {
+	customer, err := h.service.GetCustomerByPhone(c.Request.Context(), c.Param("phone"))
+	if err != nil {
+		utils.NewResponse(c).Fail(string(getByPhoneError), err)
+		return
+	}
+
+	utils.NewResponse(c).Success(http.StatusOK, customer)
+}
+
+func (h *customerHandler) GetCustomerByEmail(c *gin.Context) {
+	customer, err := h.service.GetCustomerByEmail(c.Request.Context(), c.Param("email"))
+	if err != nil {
+		utils.NewResponse(c).Fail(string(getByEmailError), err)
+		return
+	}
+
+	utils.NewResponse(c).Success(http.StatusOK, customer)
+}
+
+// UpdateCustomer merges the body into the current customer, see
+// utils.ShouldBindMergePatch, so a field can be cleared on purpose.
+func (h *customerHandler) UpdateCustomer(c *gin.Context) {
+	id := strings.Trim(c.Param("customerId"), " ")
+
+	current, err := h.service.GetCustomer(c.Request.Context(), id)
+	if err != nil {
+		utils.NewResponse(c).Fail(string(updateError), err)
+		return
+	}
+
+	request := current.Request()
+	if err := utils.ShouldBindMergePatch(c, &request); err != nil {
+		utils.NewResponse(c).BindError(string(updateError), err)
+		return
+	}
+
+	customer, err := h.service.UpdateCustomer(c.Request.Context(), id, &request)
+	if err != nil {
+		utils.NewResponse(c).Fail(string(updateError), err)
+		return
+	}
+
+	utils.NewResponse(c).Success(http.StatusOK, customer)
+}
+
+func (h *customerHandler) DeleteCustomer(c *gin.Context) {
+	id := strings.Trim(c.Param("customerId"), " ")
+
+	if err := h.service.DeleteCustomer(c.Request.Context(), id); err != nil {
+		utils.NewResponse(c).Fail(string(deleteError), err)
+		return
+	}
+
+	utils.NewResponse(c).Success(http.StatusNoContent, nil)
+}
@@ -0,0 +1,192 @@
+package custrepositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/codepnw/sales-api/database"
+	"github.com/codepnw/sales-api/modules/customers"
+	"github.com/codepnw/sales-api/pkg/errs"
+	"github.com/jmoiron/sqlx"
+)
+
+var Schemas = []database.TableSchema{
+	{
+		Table: "customers",
+		Columns: map[string]string{
+			"customer_id": database.TypeVarchar,
+			"first_name":  database.TypeVarchar,
+			"last_name":   database.TypeVarchar,
+			"phone":       database.TypeVarchar,
+			"email":       database.TypeVarchar,
+			"address":     database.TypeVarchar,
+			"created_at":  database.TypeTimestamp,
+			"updated_at":  database.TypeTimestamp,
+		},
+	},
+}
+
+type ICustomerRepo interface {
+	CreateCustomer(customer *customers.Customer) (*customers.Customer, error)
+	GetCustomers() ([]*customers.Customer, error)
+	GetCustomer(customerId string) (*customers.Customer, error)
+	GetCustomerByPhone(phone string) (*customers.Customer, error)
+	GetCustomerByEmail(email string) (*customers.Customer, error)
+	// UpdateCustomer writes every field of customer.
+	UpdateCustomer(ctx context.Context, customer *customers.Customer) (*customers.Customer, error)
+	DeleteCustomer(customerId string) error
+}
+
+type customerRepo struct {
+	db *sqlx.DB
+}
+
+func NewCustomerRepository(db *sqlx.DB) ICustomerRepo {
+	return &customerRepo{db: db}
+}
+
+const selectCustomer = `
+	SELECT "customer_id", "first_name", "last_name", "phone", "email", "address", "created_at", "updated_at"
+	FROM "customers"
+`
+
+func (r *customerRepo) CreateCustomer(customer *customers.Customer) (*customers.Customer, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	query := `
+		INSERT INTO "customers" ("first_name", "last_name", "phone", "email", "address", "created_at", "updated_at")
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING "customer_id";
+	`
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		customer.FirstName,
+		customer.LastName,
+		customer.Phone,
+		customer.Email,
+		customer.Address,
+		customer.CreatedAt,
+		customer.UpdatedAt,
+	).Scan(&customer.CustomerId)
+
+	if err != nil {
+		if database.IsUniqueViolation(err) {
+			return nil, customers.ErrCustomerExists
+		}
+		return nil, err
+	}
+
+	return customer, nil
+}
+
+func (r *customerRepo) GetCustomers() ([]*customers.Customer, error) {
+	custs := make([]*customers.Customer, 0)
+
+	query := selectCustomer + `ORDER BY "customer_id";`
+	err := r.db.Select(&custs, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return custs, nil
+}
+
+func (r *customerRepo) GetCustomer(customerId string) (*customers.Customer, error) {
+	return r.getCustomerBy(`"customer_id"`, customerId)
+}
+
+func (r *customerRepo) GetCustomerByPhone(phone string) (*customers.Customer, error) {
+	return r.getCustomerBy(`"phone"`, phone)
+}
+
+func (r *customerRepo) GetCustomerByEmail(email string) (*customers.Customer, error) {
+	return r.getCustomerBy(`"email"`, email)
+}
+
+// getCustomerBy is only called with a fixed column name, never with user input.
+func (r *customerRepo) getCustomerBy(column, value string) (*customers.Customer, error) {
+	cust := customers.Customer{}
+
+	query := selectCustomer + fmt.Sprintf(`WHERE %s = $1 LIMIT 1;`, column)
+	err := r.db.Get(&cust, query, value)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.NotFound("customer not found")
+		}
+		return nil, err
+	}
+
+	return &cust, nil
+}
+
+func (r *customerRepo) UpdateCustomer(ctx context.Context, customer *customers.Customer) (*customers.Customer, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+
+	query := `
+		UPDATE "customers"
+		SET
+			"first_name" = $1,
+			"last_name" = $2,
+			"phone" = $3,
+			"email" = $4,
+			"address" = $5,
+			"updated_at" = $6
+		WHERE "customer_id" = $7;
+	`
+	result, err := r.db.ExecContext(
+		ctx,
+		query,
+		customer.FirstName,
+		customer.LastName,
+		customer.Phone,
+		customer.Email,
+		customer.Address,
+		customer.UpdatedAt,
+		customer.CustomerId,
+	)
+	if err != nil {
+		if database.IsUniqueViolation(err) {
+			return nil, customers.ErrCustomerExists
+		}
+		return nil, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if rows == 0 {
+		return nil, errs.NotFound("customer %s not found", customer.CustomerId)
+	}
+
+	c, err := r.GetCustomer(customer.CustomerId)
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (r *customerRepo) DeleteCustomer(customerId string) error {
+	query := `DELETE FROM "customers" WHERE "customer_id" = $1;`
+
+	result, err := r.db.ExecContext(context.Background(), query, customerId)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return errs.NotFound("customer %s not found", customerId)
+	}
+
+	return nil
+}
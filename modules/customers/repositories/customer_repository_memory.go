@@ -0,0 +1,132 @@
+package custrepositories
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/codepnw/sales-api/database"
+	"github.com/codepnw/sales-api/modules/customers"
+	"github.com/codepnw/sales-api/pkg/errs"
+)
+
+type customerMemoryRepo struct {
+	db *database.MemoryDB
+}
+
+func NewCustomerMemoryRepository(db *database.MemoryDB) ICustomerRepo {
+	return &customerMemoryRepo{db: db}
+}
+
+func (r *customerMemoryRepo) CreateCustomer(customer *customers.Customer) (*customers.Customer, error) {
+	err := r.db.Update(func(tx *database.MemoryTx) error {
+		if exists(tx, customer) {
+			return customers.ErrCustomerExists
+		}
+
+		customer.CustomerId = fmt.Sprintf("C%06d", tx.NextId("customers"))
+		tx.Put("customers", customer.CustomerId, *customer)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return customer, nil
+}
+
+func (r *customerMemoryRepo) GetCustomers() ([]*customers.Customer, error) {
+	custs := make([]*customers.Customer, 0)
+
+	r.db.View(func(tx *database.MemoryTx) error {
+		for _, row := range tx.Rows("customers") {
+			cust := row.(customers.Customer)
+			custs = append(custs, &cust)
+		}
+		return nil
+	})
+	sort.Slice(custs, func(i, j int) bool { return custs[i].CustomerId < custs[j].CustomerId })
+
+	return custs, nil
+}
+
+func (r *customerMemoryRepo) GetCustomer(customerId string) (*customers.Customer, error) {
+	return r.getCustomerBy(func(c *customers.Customer) bool { return c.CustomerId == customerId })
+}
+
+func (r *customerMemoryRepo) GetCustomerByPhone(phone string) (*customers.Customer, error) {
+	return r.getCustomerBy(func(c *customers.Customer) bool { return c.Phone == phone })
+}
+
+func (r *customerMemoryRepo) GetCustomerByEmail(email string) (*customers.Customer, error) {
+	return r.getCustomerBy(func(c *customers.Customer) bool { return c.Email == email })
+}
+
+func (r *customerMemoryRepo) getCustomerBy(match func(c *customers.Customer) bool) (*customers.Customer, error) {
+	cust := customers.Customer{}
+
+	err := r.db.View(func(tx *database.MemoryTx) error {
+		for _, row := range tx.Rows("customers") {
+			if c := row.(customers.Customer); match(&c) {
+				cust = c
+				return nil
+			}
+		}
+		return errs.NotFound("customer not found")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &cust, nil
+}
+
+func (r *customerMemoryRepo) UpdateCustomer(ctx context.Context, customer *customers.Customer) (*customers.Customer, error) {
+	err := r.db.Update(func(tx *database.MemoryTx) error {
+		row, ok := tx.Get("customers", customer.CustomerId)
+		if !ok {
+			return errs.NotFound("customer not found")
+		}
+
+		current := row.(customers.Customer)
+		current.FirstName = customer.FirstName
+		current.LastName = customer.LastName
+		current.Phone = customer.Phone
+		current.Email = customer.Email
+		current.Address = customer.Address
+		current.UpdatedAt = customer.UpdatedAt
+
+		if exists(tx, &current) {
+			return customers.ErrCustomerExists
+		}
+		tx.Put("customers", current.CustomerId, current)
+
+		*customer = current
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return customer, nil
+}
+
+func (r *customerMemoryRepo) DeleteCustomer(customerId string) error {
+	return r.db.Update(func(tx *database.MemoryTx) error {
+		if !tx.Delete("customers", customerId) {
+			return errs.NotFound("customer %s not found", customerId)
+		}
+		return nil
+	})
+}
+
+// exists reports whether another customer already has the phone or email.
+func exists(tx *database.MemoryTx, customer *customers.Customer) bool {
+	for _, row := range tx.Rows("customers") {
+		c := row.(customers.Customer)
+		if c.CustomerId != customer.CustomerId && (c.Phone == customer.Phone || c.Email == customer.Email) {
+			return true
+		}
+	}
+	return false
+}
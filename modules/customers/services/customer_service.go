@@ -0,0 +1,138 @@
+package custservices
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/codepnw/sales-api/modules/customers"
+	custrepositories "github.com/codepnw/sales-api/modules/customers/repositories"
+	"github.com/codepnw/sales-api/pkg/errs"
+	"github.com/codepnw/sales-api/pkg/logs"
+	"github.com/codepnw/sales-api/pkg/utils"
+)
+
+type ICustomerService interface {
+	CreateCustomer(ctx context.Context, req *customers.CustomerRequest) (*customers.Customer, error)
+	GetCustomers(ctx context.Context) ([]*customers.Customer, error)
+	GetCustomer(ctx context.Context, customerId string) (*customers.Customer, error)
+	GetCustomerByPhone(ctx context.Context, phone string) (*customers.Customer, error)
+	GetCustomerByEmail(ctx context.Context, email string) (*customers.Customer, error)
+	UpdateCustomer(ctx context.Context, customerId string, req *customers.CustomerRequest) (*customers.Customer, error)
+	DeleteCustomer(ctx context.Context, customerId string) error
+}
+
+type customerService struct {
+	repo custrepositories.ICustomerRepo
+}
+
+func NewCustomerService(repo custrepositories.ICustomerRepo) ICustomerService {
+	return &customerService{repo: repo}
+}
+
+func (s *customerService) CreateCustomer(ctx context.Context, req *customers.CustomerRequest) (*customers.Customer, error) {
+
+	customer := customers.Customer{
+		FirstName: req.FirstName,
+		LastName:  req.LastName,
+		Phone:     strings.TrimSpace(req.Phone),
+		Email:     strings.ToLower(strings.TrimSpace(req.Email)),
+		Address:   req.Address,
+		CreatedAt: utils.LocalTime(),
+		UpdatedAt: utils.LocalTime(),
+	}
+
+	c, err := s.repo.CreateCustomer(&customer)
+	if err != nil {
+		logs.ErrorContext(ctx, err)
+		if errs.Known(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed create customer")
+	}
+
+	return c, nil
+}
+
+func (s *customerService) GetCustomers(ctx context.Context) ([]*customers.Customer, error) {
+	c, err := s.repo.GetCustomers()
+	if err != nil {
+		logs.ErrorContext(ctx, err)
+		return nil, fmt.Errorf("failed get customers")
+	}
+
+	return c, nil
+}
+
+func (s *customerService) GetCustomer(ctx context.Context, customerId string) (*customers.Customer, error) {
+	c, err := s.repo.GetCustomer(customerId)
+	if err != nil {
+		logs.ErrorContext(ctx, err)
+		if errs.Known(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed get customer")
+	}
+
+	return c, nil
+}
+
+func (s *customerService) GetCustomerByPhone(ctx context.Context, phone string) (*customers.Customer, error) {
+	c, err := s.repo.GetCustomerByPhone(strings.TrimSpace(phone))
+	if err != nil {
+		logs.ErrorContext(ctx, err)
+		if errs.Known(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed get customer")
+	}
+
+	return c, nil
+}
+
+func (s *customerService) GetCustomerByEmail(ctx context.Context, email string) (*customers.Customer, error) {
+	c, err := s.repo.GetCustomerByEmail(strings.ToLower(strings.TrimSpace(email)))
+	if err != nil {
+		logs.ErrorContext(ctx, err)
+		if errs.Known(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed get customer")
+	}
+
+	return c, nil
+}
+
+func (s *customerService) UpdateCustomer(ctx context.Context, customerId string, req *customers.CustomerRequest) (*customers.Customer, error) {
+	customer := customers.Customer{
+		CustomerId: customerId,
+		FirstName:  req.FirstName,
+		LastName:   req.LastName,
+		Phone:      strings.TrimSpace(req.Phone),
+		Email:      strings.ToLower(strings.TrimSpace(req.Email)),
+		Address:    req.Address,
+		UpdatedAt:  utils.LocalTime(),
+	}
+
+	c, err := s.repo.UpdateCustomer(ctx, &customer)
+	if err != nil {
+		logs.ErrorContext(ctx, err)
+		if errs.Known(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed update customer")
+	}
+
+	return c, nil
+}
+
+func (s *customerService) DeleteCustomer(ctx context.Context, customerId string) error {
+	if err := s.repo.DeleteCustomer(customerId); err != nil {
+		logs.ErrorContext(ctx, err)
+		if errs.Known(err) {
+			return err
+		}
+		return fmt.Errorf("failed delete customer")
+	}
+	return nil
+}
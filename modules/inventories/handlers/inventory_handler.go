@@ -0,0 +1,62 @@
+package invhandlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/codepnw/sales-api/modules/inventories"
+	invservices "github.com/codepnw/sales-api/modules/inventories/services"
+	"github.com/codepnw/sales-api/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type inventoryHandler struct {
+	service invservices.IInventoryService
+}
+
+func NewInventoryHandler(service invservices.IInventoryService) *inventoryHandler {
+	return &inventoryHandler{service: service}
+}
+
+type inventoryErr string
+
+const (
+	adjustError  inventoryErr = "inventories-001"
+	getLogsError inventoryErr = "inventories-002"
+)
+
+func (h *inventoryHandler) AdjustStock(c *gin.Context) {
+	productId := strings.Trim(c.Param("productId"), " ")
+	request := inventories.StockAdjustmentRequest{}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.NewResponse(c).BindError(string(adjustError), err)
+		return
+	}
+
+	adjustment, err := h.service.AdjustStock(c.Request.Context(), productId, &request)
+	if err != nil {
+		utils.NewResponse(c).Fail(string(adjustError), err)
+		return
+	}
+
+	utils.NewResponse(c).Success(http.StatusCreated, adjustment)
+}
+
+func (h *inventoryHandler) GetInventoryLogs(c *gin.Context) {
+	productId := strings.Trim(c.Param("productId"), " ")
+	filter := inventories.InventoryLogFilter{}
+
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		utils.NewResponse(c).BindError(string(getLogsError), err)
+		return
+	}
+
+	logs, pagination, err := h.service.GetInventoryLogs(c.Request.Context(), productId, &filter)
+	if err != nil {
+		utils.NewResponse(c).Fail(string(getLogsError), err)
+		return
+	}
+
+	utils.NewResponse(c).SuccessWithPagination(http.StatusOK, logs, pagination)
+}
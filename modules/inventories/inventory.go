@@ -0,0 +1,75 @@
+package inventories
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/codepnw/sales-api/pkg/errs"
+	"github.com/codepnw/sales-api/pkg/utils"
+)
+
+const (
+	AdjustReceive string = "RECEIVE"
+	AdjustDamage  string = "DAMAGE"
+	AdjustRecount string = "RECOUNT"
+)
+
+var (
+	ErrInvalidAdjustment = errs.Validation("invalid stock adjustment")
+	ErrInsufficientStock = errs.Conflict("insufficient stock")
+)
+
+// InventoryLog is one stock change, logs are listed newest first by Date,
+// which the database sets, and by Seq, the insert order, within one date.
+type InventoryLog struct {
+	InventoryLogId string    `db:"inventory_log_id" json:"inventoryLogId"`
+	ProductId      string    `db:"product_id" json:"productId"`
+	Change         string    `db:"change" json:"change"`
+	Description    string    `db:"description" json:"description"`
+	Date           time.Time `db:"date" json:"date"`
+	Seq            int64     `db:"seq" json:"-"`
+}
+
+// StockAdjustmentRequest needs a quantity above zero, only a RECOUNT may
+// set the stock to 0.
+type StockAdjustmentRequest struct {
+	Type        string `json:"type" form:"type" binding:"required,oneof=RECEIVE DAMAGE RECOUNT"`
+	Quantity    int    `json:"quantity" form:"quantity" binding:"gte=0,required_unless=Type RECOUNT"`
+	Description string `json:"description" form:"description" binding:"max=255"`
+}
+
+// StockAdjustment carries the log to write, the repository fills in its id
+// and change together with the resulting stock.
+type StockAdjustment struct {
+	ProductId string        `json:"productId"`
+	Type      string        `json:"type"`
+	Quantity  int           `json:"quantity"`
+	Stock     int           `json:"stock"`
+	Log       *InventoryLog `json:"log"`
+}
+
+type InventoryLogFilter struct {
+	utils.PageQuery
+}
+
+// Apply returns the stock after the adjustment and the signed change that
+// goes into inventory_logs. RECOUNT sets the stock to the counted quantity.
+func (a *StockAdjustment) Apply(stock int) (int, string, error) {
+	var next int
+
+	switch a.Type {
+	case AdjustReceive:
+		next = stock + a.Quantity
+	case AdjustDamage:
+		if a.Quantity > stock {
+			return 0, "", fmt.Errorf("%w: product %s has %d left", ErrInsufficientStock, a.ProductId, stock)
+		}
+		next = stock - a.Quantity
+	case AdjustRecount:
+		next = a.Quantity
+	default:
+		return 0, "", fmt.Errorf("%w: type %q is not supported", ErrInvalidAdjustment, a.Type)
+	}
+
+	return next, fmt.Sprintf("%+d", next-stock), nil
+}
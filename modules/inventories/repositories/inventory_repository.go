@@ -0,0 +1,132 @@
+package invrepositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/codepnw/sales-api/database"
+	"github.com/codepnw/sales-api/modules/inventories"
+	"github.com/codepnw/sales-api/pkg/errs"
+	"github.com/jmoiron/sqlx"
+)
+
+var Schemas = []database.TableSchema{
+	{
+		Table: "inventory_logs",
+		Columns: map[string]string{
+			"inventory_log_id": database.TypeUUID,
+			"product_id":       database.TypeVarchar,
+			"change":           database.TypeVarchar,
+			"description":      database.TypeVarchar,
+			"date":             database.TypeTimestamp,
+			"seq":              database.TypeBigInt,
+		},
+	},
+	{
+		Table: "products",
+		Columns: map[string]string{
+			"product_id": database.TypeVarchar,
+			"stock":      database.TypeInt,
+		},
+	},
+}
+
+type IInventoryRepo interface {
+	AdjustStock(adjustment *inventories.StockAdjustment) (*inventories.StockAdjustment, error)
+	GetInventoryLogs(productId string, filter *inventories.InventoryLogFilter) ([]*inventories.InventoryLog, int, error)
+}
+
+type inventoryRepo struct {
+	db *sqlx.DB
+}
+
+func NewInventoryRepository(db *sqlx.DB) IInventoryRepo {
+	return &inventoryRepo{db: db}
+}
+
+// AdjustStock updates products.stock and writes the inventory log in one
+// transaction, the product row stays locked until both are saved.
+func (r *inventoryRepo) AdjustStock(adjustment *inventories.StockAdjustment) (*inventories.StockAdjustment, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var stock int
+
+	query := fmt.Sprintf(`SELECT "stock" FROM "products" WHERE "product_id" = $1 %s;`, database.ForUpdate(tx))
+	if err := tx.GetContext(ctx, &stock, query, adjustment.ProductId); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.NotFound("product %s not found", adjustment.ProductId)
+		}
+		return nil, err
+	}
+
+	next, change, err := adjustment.Apply(stock)
+	if err != nil {
+		return nil, err
+	}
+
+	query = `UPDATE "products" SET "stock" = $1, "version" = "version" + 1 WHERE "product_id" = $2;`
+	if _, err := tx.ExecContext(ctx, query, next, adjustment.ProductId); err != nil {
+		return nil, err
+	}
+
+	log := adjustment.Log
+	log.ProductId = adjustment.ProductId
+	log.Change = change
+
+	query = `
+		INSERT INTO "inventory_logs" ("product_id", "change", "description")
+		VALUES ($1, $2, $3)
+		RETURNING "inventory_log_id", "date";
+	`
+	err = tx.QueryRowContext(
+		ctx,
+		query,
+		log.ProductId,
+		log.Change,
+		log.Description,
+	).Scan(&log.InventoryLogId, &log.Date)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	adjustment.Stock = next
+
+	return adjustment, nil
+}
+
+func (r *inventoryRepo) GetInventoryLogs(productId string, filter *inventories.InventoryLogFilter) ([]*inventories.InventoryLog, int, error) {
+	logs := make([]*inventories.InventoryLog, 0)
+	var total int
+
+	query := `SELECT COUNT(*) FROM "inventory_logs" WHERE "product_id" = $1;`
+	if err := r.db.Get(&total, query, productId); err != nil {
+		return nil, 0, err
+	}
+
+	query = `
+		SELECT "inventory_log_id", "product_id", "change", COALESCE("description", '') AS "description", "date"
+		FROM "inventory_logs"
+		WHERE "product_id" = $1
+		ORDER BY "date" DESC, "seq" DESC
+		LIMIT $2 OFFSET $3;
+	`
+	err := r.db.Select(&logs, query, productId, filter.Limit, filter.Offset())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return logs, total, nil
+}
@@ -0,0 +1,87 @@
+package invrepositories
+
+import (
+	"sort"
+
+	"github.com/codepnw/sales-api/database"
+	"github.com/codepnw/sales-api/modules/inventories"
+	"github.com/codepnw/sales-api/modules/products"
+	"github.com/codepnw/sales-api/pkg/errs"
+	"github.com/codepnw/sales-api/pkg/utils"
+	"github.com/google/uuid"
+)
+
+type inventoryMemoryRepo struct {
+	db *database.MemoryDB
+}
+
+func NewInventoryMemoryRepository(db *database.MemoryDB) IInventoryRepo {
+	return &inventoryMemoryRepo{db: db}
+}
+
+func (r *inventoryMemoryRepo) AdjustStock(adjustment *inventories.StockAdjustment) (*inventories.StockAdjustment, error) {
+	err := r.db.Update(func(tx *database.MemoryTx) error {
+		row, ok := tx.Get("products", adjustment.ProductId)
+		if !ok {
+			return errs.NotFound("product %s not found", adjustment.ProductId)
+		}
+		product := row.(products.Product)
+
+		next, change, err := adjustment.Apply(int(product.Stock))
+		if err != nil {
+			return err
+		}
+
+		product.Stock = uint(next)
+		product.Version++
+		tx.Put("products", product.ProductID, product)
+
+		log := adjustment.Log
+		log.InventoryLogId = uuid.NewString()
+		log.ProductId = adjustment.ProductId
+		log.Change = change
+		log.Date = utils.LocalTime()
+		log.Seq = int64(tx.NextId("inventory_logs"))
+		tx.Put("inventory_logs", log.InventoryLogId, *log)
+
+		adjustment.Stock = next
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return adjustment, nil
+}
+
+func (r *inventoryMemoryRepo) GetInventoryLogs(productId string, filter *inventories.InventoryLogFilter) ([]*inventories.InventoryLog, int, error) {
+	logs := make([]*inventories.InventoryLog, 0)
+
+	r.db.View(func(tx *database.MemoryTx) error {
+		for _, row := range tx.Rows("inventory_logs") {
+			if log := row.(inventories.InventoryLog); log.ProductId == productId {
+				logs = append(logs, &log)
+			}
+		}
+		return nil
+	})
+
+	sort.Slice(logs, func(i, j int) bool {
+		if !logs[i].Date.Equal(logs[j].Date) {
+			return logs[i].Date.After(logs[j].Date)
+		}
+		return logs[i].Seq > logs[j].Seq
+	})
+	total := len(logs)
+
+	offset := filter.Offset()
+	if offset > total {
+		offset = total
+	}
+	end := offset + filter.Limit
+	if end > total {
+		end = total
+	}
+
+	return logs[offset:end], total, nil
+}
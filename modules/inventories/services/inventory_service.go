@@ -0,0 +1,72 @@
+package invservices
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/codepnw/sales-api/modules/inventories"
+	invrepositories "github.com/codepnw/sales-api/modules/inventories/repositories"
+	"github.com/codepnw/sales-api/pkg/errs"
+	"github.com/codepnw/sales-api/pkg/logs"
+	"github.com/codepnw/sales-api/pkg/metrics"
+	"github.com/codepnw/sales-api/pkg/utils"
+)
+
+type IInventoryService interface {
+	AdjustStock(ctx context.Context, productId string, req *inventories.StockAdjustmentRequest) (*inventories.StockAdjustment, error)
+	GetInventoryLogs(ctx context.Context, productId string, filter *inventories.InventoryLogFilter) ([]*inventories.InventoryLog, *utils.Pagination, error)
+}
+
+type inventoryService struct {
+	repo invrepositories.IInventoryRepo
+}
+
+func NewInventoryService(repo invrepositories.IInventoryRepo) IInventoryService {
+	return &inventoryService{repo: repo}
+}
+
+func (s *inventoryService) AdjustStock(ctx context.Context, productId string, req *inventories.StockAdjustmentRequest) (*inventories.StockAdjustment, error) {
+	description := strings.ToLower(req.Type)
+	if req.Description != "" {
+		description += ": " + req.Description
+	}
+
+	adjustment := inventories.StockAdjustment{
+		ProductId: productId,
+		Type:      req.Type,
+		Quantity:  req.Quantity,
+		Log:       &inventories.InventoryLog{Description: description},
+	}
+
+	a, err := s.repo.AdjustStock(&adjustment)
+	if err != nil {
+		logs.ErrorContext(ctx, err)
+		if errors.Is(err, inventories.ErrInsufficientStock) {
+			metrics.StockRejections.WithLabelValues("adjustment").Inc()
+		}
+		if errs.Known(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed adjust stock")
+	}
+	// a recount of an empty product to 0 changes nothing
+	if a.Stock == 0 && a.Log.Change != "+0" {
+		metrics.ProductsOutOfStock.WithLabelValues("adjustment").Inc()
+	}
+
+	return a, nil
+}
+
+func (s *inventoryService) GetInventoryLogs(ctx context.Context, productId string, filter *inventories.InventoryLogFilter) ([]*inventories.InventoryLog, *utils.Pagination, error) {
+	filter.Normalize()
+
+	l, total, err := s.repo.GetInventoryLogs(productId, filter)
+	if err != nil {
+		logs.ErrorContext(ctx, err)
+		return nil, nil, fmt.Errorf("failed get inventory logs")
+	}
+
+	return l, utils.NewPagination(filter.PageQuery, total), nil
+}
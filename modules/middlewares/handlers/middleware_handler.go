@@ -0,0 +1,81 @@
+package mwhandlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/codepnw/sales-api/config"
+	"github.com/codepnw/sales-api/modules/middlewares"
+	mwservices "github.com/codepnw/sales-api/modules/middlewares/services"
+	"github.com/codepnw/sales-api/pkg/auth"
+	"github.com/codepnw/sales-api/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type IMiddlewareHandler interface {
+	JwtAuth() gin.HandlerFunc
+	Authorize(minRole string) gin.HandlerFunc
+}
+
+type middlewareHandler struct {
+	service mwservices.IMiddlewareService
+	cfg     config.ConfigJwt
+}
+
+func NewMiddlewareHandler(service mwservices.IMiddlewareService, cfg config.ConfigJwt) IMiddlewareHandler {
+	return &middlewareHandler{service: service, cfg: cfg}
+}
+
+type middlewareErr string
+
+const (
+	jwtAuthError   middlewareErr = "middlewares-001"
+	authorizeError middlewareErr = "middlewares-002"
+)
+
+func (h *middlewareHandler) JwtAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+
+		claims, err := auth.ParseToken(h.cfg, auth.Access, token)
+		if err != nil {
+			utils.NewResponse(c).Error(
+				http.StatusUnauthorized,
+				string(jwtAuthError),
+				err.Error(),
+			)
+			c.Abort()
+			return
+		}
+
+		if !h.service.FindAccessToken(c.Request.Context(), claims.UserId, token) {
+			utils.NewResponse(c).Error(
+				http.StatusUnauthorized,
+				string(jwtAuthError),
+				"no permission to access",
+			)
+			c.Abort()
+			return
+		}
+
+		c.Set(middlewares.ContextUserId, claims.UserId)
+		c.Set(middlewares.ContextRoleId, claims.RoleId)
+		c.Next()
+	}
+}
+
+// Authorize must run after JwtAuth, it lets the request through when the
+// user's role is at least minRole.
+func (h *middlewareHandler) Authorize(minRole string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		roleId := c.GetInt(middlewares.ContextRoleId)
+
+		if err := h.service.Authorize(c.Request.Context(), roleId, minRole); err != nil {
+			utils.NewResponse(c).Fail(string(authorizeError), err)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,32 @@
+package middlewares
+
+const (
+	RoleEmployee   string = "employee"
+	RoleAdmin      string = "admin"
+	RoleSuperAdmin string = "superadmin"
+)
+
+const (
+	ContextUserId string = "userId"
+	ContextRoleId string = "roleId"
+)
+
+// roleRanks orders the seeded user_roles, a higher rank includes every lower one.
+var roleRanks = map[string]int{
+	RoleEmployee:   1,
+	RoleAdmin:      2,
+	RoleSuperAdmin: 3,
+}
+
+type Role struct {
+	RoleId int    `db:"role_id" json:"roleId"`
+	Title  string `db:"title" json:"title"`
+}
+
+func (r *Role) Satisfies(minRole string) bool {
+	rank, ok := roleRanks[r.Title]
+	if !ok {
+		return false
+	}
+	return rank >= roleRanks[minRole]
+}
@@ -0,0 +1,77 @@
+package mwrepositories
+
+import (
+	"database/sql"
+
+	"github.com/codepnw/sales-api/database"
+	"github.com/codepnw/sales-api/modules/middlewares"
+	"github.com/codepnw/sales-api/pkg/errs"
+	"github.com/jmoiron/sqlx"
+)
+
+var Schemas = []database.TableSchema{
+	{
+		Table: "oauth",
+		Columns: map[string]string{
+			"user_id":      database.TypeVarchar,
+			"access_token": database.TypeVarchar,
+		},
+	},
+	{
+		Table: "user_roles",
+		Columns: map[string]string{
+			"role_id": database.TypeInt,
+			"title":   database.TypeVarchar,
+		},
+	},
+}
+
+type IMiddlewareRepo interface {
+	FindAccessToken(userId, accessToken string) bool
+	GetRole(roleId int) (*middlewares.Role, error)
+}
+
+type middlewareRepo struct {
+	db *sqlx.DB
+}
+
+func NewMiddlewareRepository(db *sqlx.DB) IMiddlewareRepo {
+	return &middlewareRepo{db: db}
+}
+
+func (r *middlewareRepo) FindAccessToken(userId, accessToken string) bool {
+	var found bool
+
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM "oauth"
+			WHERE "user_id" = $1
+			AND "access_token" = $2
+		);
+	`
+	if err := r.db.Get(&found, query, userId, accessToken); err != nil {
+		return false
+	}
+
+	return found
+}
+
+func (r *middlewareRepo) GetRole(roleId int) (*middlewares.Role, error) {
+	role := middlewares.Role{}
+
+	query := `
+		SELECT "role_id", "title"
+		FROM "user_roles"
+		WHERE "role_id" = $1
+		LIMIT 1;
+	`
+	err := r.db.Get(&role, query, roleId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.NotFound("role %d not found", roleId)
+		}
+		return nil, err
+	}
+
+	return &role, nil
+}
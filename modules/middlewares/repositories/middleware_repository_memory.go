@@ -0,0 +1,65 @@
+package mwrepositories
+
+import (
+	"strconv"
+
+	"github.com/codepnw/sales-api/database"
+	"github.com/codepnw/sales-api/modules/middlewares"
+	"github.com/codepnw/sales-api/modules/users"
+	"github.com/codepnw/sales-api/pkg/errs"
+)
+
+type middlewareMemoryRepo struct {
+	db *database.MemoryDB
+}
+
+// NewMiddlewareMemoryRepository seeds user_roles the way the migrations do,
+// the memory store has no migrations to run.
+func NewMiddlewareMemoryRepository(db *database.MemoryDB) IMiddlewareRepo {
+	db.Update(func(tx *database.MemoryTx) error {
+		titles := []string{middlewares.RoleEmployee, middlewares.RoleAdmin, middlewares.RoleSuperAdmin}
+		for i, title := range titles {
+			key := strconv.Itoa(i + 1)
+			if _, ok := tx.Get("user_roles", key); !ok {
+				tx.Put("user_roles", key, middlewares.Role{RoleId: i + 1, Title: title})
+			}
+		}
+		return nil
+	})
+
+	return &middlewareMemoryRepo{db: db}
+}
+
+func (r *middlewareMemoryRepo) FindAccessToken(userId, accessToken string) bool {
+	var found bool
+
+	r.db.View(func(tx *database.MemoryTx) error {
+		for _, row := range tx.Rows("oauth") {
+			if o := row.(users.Oauth); o.UserId == userId && o.AccessToken == accessToken {
+				found = true
+				break
+			}
+		}
+		return nil
+	})
+
+	return found
+}
+
+func (r *middlewareMemoryRepo) GetRole(roleId int) (*middlewares.Role, error) {
+	role := middlewares.Role{}
+
+	err := r.db.View(func(tx *database.MemoryTx) error {
+		row, ok := tx.Get("user_roles", strconv.Itoa(roleId))
+		if !ok {
+			return errs.NotFound("role %d not found", roleId)
+		}
+		role = row.(middlewares.Role)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &role, nil
+}
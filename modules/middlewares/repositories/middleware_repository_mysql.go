@@ -0,0 +1,52 @@
+package mwrepositories
+
+import (
+	"github.com/codepnw/sales-api/modules/middlewares"
+	"github.com/codepnw/sales-api/pkg/errs"
+	"gorm.io/gorm"
+)
+
+type middlewareMysqlRepo struct {
+	db *gorm.DB
+}
+
+func NewMiddlewareMysqlRepository(db *gorm.DB) IMiddlewareRepo {
+	return &middlewareMysqlRepo{db: db}
+}
+
+func (r *middlewareMysqlRepo) FindAccessToken(userId, accessToken string) bool {
+	var found bool
+
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM oauth
+			WHERE user_id = ?
+			AND access_token = ?
+		);
+	`
+	if err := r.db.Raw(query, userId, accessToken).Scan(&found).Error; err != nil {
+		return false
+	}
+
+	return found
+}
+
+func (r *middlewareMysqlRepo) GetRole(roleId int) (*middlewares.Role, error) {
+	role := middlewares.Role{}
+
+	query := `
+		SELECT role_id, title
+		FROM user_roles
+		WHERE role_id = ?
+		LIMIT 1;
+	`
+	result := r.db.Raw(query, roleId).Scan(&role)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, errs.NotFound("role %d not found", roleId)
+	}
+
+	return &role, nil
+}
@@ -0,0 +1,39 @@
+package mwservices
+
+import (
+	"context"
+
+	mwrepositories "github.com/codepnw/sales-api/modules/middlewares/repositories"
+	"github.com/codepnw/sales-api/pkg/errs"
+	"github.com/codepnw/sales-api/pkg/logs"
+)
+
+type IMiddlewareService interface {
+	FindAccessToken(ctx context.Context, userId, accessToken string) bool
+	Authorize(ctx context.Context, roleId int, minRole string) error
+}
+
+type middlewareService struct {
+	repo mwrepositories.IMiddlewareRepo
+}
+
+func NewMiddlewareService(repo mwrepositories.IMiddlewareRepo) IMiddlewareService {
+	return &middlewareService{repo: repo}
+}
+
+func (s *middlewareService) FindAccessToken(ctx context.Context, userId, accessToken string) bool {
+	return s.repo.FindAccessToken(userId, accessToken)
+}
+
+func (s *middlewareService) Authorize(ctx context.Context, roleId int, minRole string) error {
+	role, err := s.repo.GetRole(roleId)
+	if err != nil {
+		logs.ErrorContext(ctx, err)
+		return errs.Forbidden("no permission to access")
+	}
+
+	if !role.Satisfies(minRole) {
+		return errs.Forbidden("no permission to access")
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package monitorhandlers
+
+import (
+	"net/http"
+	"runtime"
+
+	"github.com/codepnw/sales-api/config"
+	"github.com/codepnw/sales-api/database"
+	"github.com/codepnw/sales-api/modules/monitors"
+	"github.com/codepnw/sales-api/pkg/errs"
+	"github.com/codepnw/sales-api/pkg/logs"
+	"github.com/codepnw/sales-api/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type IMonitorHandler interface {
+	Health(c *gin.Context)
+	Ready(c *gin.Context)
+	Version(c *gin.Context)
+	GetLogLevel(c *gin.Context)
+	SetLogLevel(c *gin.Context)
+}
+
+type monitorHandler struct {
+	cfg config.IConfig
+}
+
+func NewMonitorHandler(cfg config.IConfig) IMonitorHandler {
+	return &monitorHandler{cfg: cfg}
+}
+
+type monitorErr string
+
+const (
+	readyError    monitorErr = "monitors-001"
+	logLevelError monitorErr = "monitors-002"
+)
+
+// Health is the liveness probe, it only tells that the process is serving.
+func (h *monitorHandler) Health(c *gin.Context) {
+	utils.NewResponse(c).Success(http.StatusOK, &monitors.Health{Status: "ok"})
+}
+
+// Ready is the readiness probe, it fails with 503 until the database answers
+// and its migrations are clean.
+func (h *monitorHandler) Ready(c *gin.Context) {
+	ready, err := database.Ready(c.Request.Context(), h.cfg.DB().Driver())
+	if err != nil {
+		utils.NewResponse(c).Error(
+			http.StatusServiceUnavailable,
+			string(readyError),
+			err.Error(),
+		)
+		return
+	}
+
+	utils.NewResponse(c).Success(http.StatusOK, ready)
+}
+
+func (h *monitorHandler) Version(c *gin.Context) {
+	utils.NewResponse(c).Success(http.StatusOK, &monitors.BuildInfo{
+		Version:   h.cfg.App().Version(),
+		Commit:    config.Commit,
+		BuildTime: config.BuildTime,
+		GoVersion: runtime.Version(),
+	})
+}
+
+func (h *monitorHandler) GetLogLevel(c *gin.Context) {
+	utils.NewResponse(c).Success(http.StatusOK, &monitors.LogLevel{Level: logs.Level().String()})
+}
+
+// SetLogLevel changes the level of the running logger, e.g. to debug while
+// chasing an issue, it goes back to log.level on the next restart.
+func (h *monitorHandler) SetLogLevel(c *gin.Context) {
+	request := monitors.LogLevel{}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.NewResponse(c).BindError(string(logLevelError), err)
+		return
+	}
+
+	level, err := zapcore.ParseLevel(request.Level)
+	if err != nil {
+		utils.NewResponse(c).Fail(string(logLevelError), errs.InvalidField("level", "%s", err))
+		return
+	}
+
+	logs.Level().SetLevel(level)
+	logs.InfoContext(c.Request.Context(), "log level changed", zap.String("level", level.String()))
+
+	utils.NewResponse(c).Success(http.StatusOK, &monitors.LogLevel{Level: level.String()})
+}
@@ -0,0 +1,16 @@
+package monitors
+
+type Health struct {
+	Status string `json:"status"`
+}
+
+type BuildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"buildTime"`
+	GoVersion string `json:"goVersion"`
+}
+
+type LogLevel struct {
+	Level string `json:"level" binding:"required"`
+}
@@ -0,0 +1,92 @@
+package orderhandlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/codepnw/sales-api/modules/orders"
+	orderservices "github.com/codepnw/sales-api/modules/orders/services"
+	"github.com/codepnw/sales-api/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type orderHandler struct {
+	service orderservices.IOrderService
+}
+
+func NewOrderHandler(service orderservices.IOrderService) *orderHandler {
+	return &orderHandler{service: service}
+}
+
+type orderErr string
+
+const (
+	createError     orderErr = "orders-001"
+	getOneError     orderErr = "orders-002"
+	getAllError     orderErr = "orders-003"
+	statusError     orderErr = "orders-004"
+	transitionError orderErr = "orders-005"
+)
+
+func (h *orderHandler) CreateOrder(c *gin.Context) {
+	request := orders.OrderRequest{}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.NewResponse(c).BindError(string(createError), err)
+		return
+	}
+
+	order, err := h.service.CreateOrder(c.Request.Context(), &request)
+	if err != nil {
+		utils.NewResponse(c).Fail(string(createError), err)
+		return
+	}
+
+	utils.NewResponse(c).Success(http.StatusCreated, order)
+}
+
+func (h *orderHandler) GetOrders(c *gin.Context) {
+	orders, err := h.service.GetOrders(c.Request.Context())
+	if err != nil {
+		utils.NewResponse(c).Fail(string(getAllError), err)
+		return
+	}
+
+	utils.NewResponse(c).Success(http.StatusOK, orders)
+}
+
+func (h *orderHandler) GetOrder(c *gin.Context) {
+	id := strings.Trim(c.Param("orderId"), " ")
+
+	order, err := h.service.GetOrder(c.Request.Context(), id)
+	if err != nil {
+		utils.NewResponse(c).Fail(string(getOneError), err)
+		return
+	}
+
+	utils.NewResponse(c).Success(http.StatusOK, order)
+}
+
+func (h *orderHandler) UpdateOrderStatus(c *gin.Context) {
+	id := strings.Trim(c.Param("orderId"), " ")
+	request := orders.OrderStatusRequest{}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.NewResponse(c).BindError(string(statusError), err)
+		return
+	}
+
+	order, err := h.service.UpdateOrderStatus(c.Request.Context(), id, &request)
+	if err != nil {
+		code := statusError
+		if errors.Is(err, orders.ErrInvalidTransition) {
+			code = transitionError
+		}
+
+		utils.NewResponse(c).Fail(string(code), err)
+		return
+	}
+
+	utils.NewResponse(c).Success(http.StatusOK, order)
+}
@@ -0,0 +1,78 @@
+package orders
+
+import (
+	"time"
+
+	"github.com/codepnw/sales-api/pkg/errs"
+)
+
+const (
+	StatusWaiting   string = "WAITING"
+	StatusCompleted string = "COMPLETED"
+	StatusCancel    string = "CANCEL"
+)
+
+const (
+	PaymentCash     string = "CASH"
+	PaymentTransfer string = "TRANSFER"
+	PaymentEtc      string = "ETC"
+)
+
+var (
+	ErrInvalidOrder      = errs.Validation("invalid order")
+	ErrInsufficientStock = errs.Conflict("insufficient stock")
+	ErrInvalidTransition = errs.Conflict("invalid status transition")
+)
+
+// transitions lists the statuses an order may move to from each status,
+// COMPLETED and CANCEL are final.
+var transitions = map[string][]string{
+	StatusWaiting: {StatusCompleted, StatusCancel},
+}
+
+func CanTransition(from, to string) bool {
+	for _, status := range transitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+type Order struct {
+	OrderId       string       `db:"order_id" json:"orderId"`
+	CustomerId    string       `db:"customer_id" json:"customerId"`
+	TotalAmount   float64      `db:"total_amount" json:"totalAmount"`
+	PaymentMethod string       `db:"payment_method" json:"paymentMethod"`
+	Status        string       `db:"status" json:"status"`
+	OrderDate     time.Time    `db:"order_date" json:"orderDate"`
+	Items         []*OrderItem `db:"-" json:"items,omitempty"`
+	// RefundDue is what was paid on an order that is being cancelled.
+	RefundDue float64 `db:"-" json:"refundDue,omitempty"`
+}
+
+type OrderItem struct {
+	OrderItemId string  `db:"order_item_id" json:"orderItemId"`
+	OrderId     string  `db:"order_id" json:"orderId"`
+	ProductId   string  `db:"product_id" json:"productId"`
+	Quantity    int     `db:"quantity" json:"quantity"`
+	Price       float64 `db:"price" json:"price"`
+	Discount    float64 `db:"discount" json:"discount"`
+	// Stock is what the product has left once the order took this item.
+	Stock int `db:"-" json:"-"`
+}
+
+type OrderRequest struct {
+	CustomerId    string              `json:"customerId" form:"customer_id" binding:"required"`
+	PaymentMethod string              `json:"paymentMethod" form:"payment_method" binding:"required,oneof=CASH TRANSFER ETC"`
+	Items         []*OrderItemRequest `json:"items" form:"items" binding:"required,min=1,dive,required"`
+}
+
+type OrderStatusRequest struct {
+	Status string `json:"status" form:"status"`
+}
+
+type OrderItemRequest struct {
+	ProductId string `json:"productId" form:"product_id" binding:"required"`
+	Quantity  int    `json:"quantity" form:"quantity" binding:"gt=0"`
+}
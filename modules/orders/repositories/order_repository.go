@@ -0,0 +1,329 @@
+package orderrepositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/codepnw/sales-api/database"
+	"github.com/codepnw/sales-api/modules/orders"
+	"github.com/codepnw/sales-api/pkg/errs"
+	"github.com/jmoiron/sqlx"
+)
+
+var Schemas = []database.TableSchema{
+	{
+		Table: "orders",
+		Columns: map[string]string{
+			"order_id":       database.TypeVarchar,
+			"customer_id":    database.TypeVarchar,
+			"total_amount":   database.TypeFloat,
+			"payment_method": database.TypeEnum,
+			"status":         database.TypeEnum,
+			"order_date":     database.TypeTimestamp,
+		},
+	},
+	{
+		Table: "order_items",
+		Columns: map[string]string{
+			"order_item_id": database.TypeUUID,
+			"order_id":      database.TypeVarchar,
+			"product_id":    database.TypeVarchar,
+			"quantity":      database.TypeInt,
+			"price":         database.TypeFloat,
+			"discount":      database.TypeFloat,
+		},
+	},
+	{
+		Table: "products",
+		Columns: map[string]string{
+			"product_id": database.TypeVarchar,
+			"price":      database.TypeFloat,
+			"discount":   database.TypeFloat,
+			"stock":      database.TypeInt,
+		},
+	},
+	{
+		Table: "inventory_logs",
+		Columns: map[string]string{
+			"product_id":  database.TypeVarchar,
+			"change":      database.TypeVarchar,
+			"description": database.TypeVarchar,
+		},
+	},
+}
+
+type IOrderRepo interface {
+	CreateOrder(order *orders.Order) (*orders.Order, error)
+	GetOrders() ([]*orders.Order, error)
+	GetOrder(orderId string) (*orders.Order, error)
+	UpdateOrderStatus(orderId, from, to string) error
+	CancelOrder(orderId, from string) error
+}
+
+type orderRepo struct {
+	db *sqlx.DB
+}
+
+func NewOrderRepository(db *sqlx.DB) IOrderRepo {
+	return &orderRepo{db: db}
+}
+
+// CreateOrder inserts the order with its items, takes the products out of
+// stock and writes the inventory logs in one transaction. Prices and
+// discounts are copied from products at the time of the order.
+func (r *orderRepo) CreateOrder(order *orders.Order) (*orders.Order, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO "orders" ("customer_id", "total_amount", "payment_method", "status", "order_date")
+		VALUES ($1, 0, $2, $3, $4)
+		RETURNING "order_id";
+	`
+	err = tx.QueryRowContext(
+		ctx,
+		query,
+		order.CustomerId,
+		order.PaymentMethod,
+		order.Status,
+		order.OrderDate,
+	).Scan(&order.OrderId)
+
+	if err != nil {
+		if database.IsForeignKeyViolation(err) {
+			return nil, fmt.Errorf("%w: customer %s not found", orders.ErrInvalidOrder, order.CustomerId)
+		}
+		return nil, err
+	}
+
+	// lock products in a fixed order so concurrent orders can't deadlock
+	items := make([]*orders.OrderItem, len(order.Items))
+	copy(items, order.Items)
+	sort.Slice(items, func(i, j int) bool { return items[i].ProductId < items[j].ProductId })
+
+	var total float64
+	for _, item := range items {
+		item.OrderId = order.OrderId
+
+		if err := r.addItem(ctx, tx, item); err != nil {
+			return nil, err
+		}
+		total += (item.Price - item.Discount) * float64(item.Quantity)
+	}
+
+	query = `UPDATE "orders" SET "total_amount" = $1 WHERE "order_id" = $2;`
+	if _, err := tx.ExecContext(ctx, query, total, order.OrderId); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	order.TotalAmount = total
+
+	return order, nil
+}
+
+func (r *orderRepo) addItem(ctx context.Context, tx *sqlx.Tx, item *orders.OrderItem) error {
+	var stock int
+
+	query := fmt.Sprintf(`
+		SELECT "price", "discount", "stock"
+		FROM "products"
+		WHERE "product_id" = $1
+		%s;
+	`, database.ForUpdate(tx))
+	err := tx.QueryRowContext(ctx, query, item.ProductId).Scan(&item.Price, &item.Discount, &stock)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("%w: product %s not found", orders.ErrInvalidOrder, item.ProductId)
+		}
+		return err
+	}
+
+	if stock < item.Quantity {
+		return fmt.Errorf("%w: product %s has %d left", orders.ErrInsufficientStock, item.ProductId, stock)
+	}
+
+	query = `
+		INSERT INTO "order_items" ("order_id", "product_id", "quantity", "price", "discount")
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING "order_item_id";
+	`
+	err = tx.QueryRowContext(
+		ctx,
+		query,
+		item.OrderId,
+		item.ProductId,
+		item.Quantity,
+		item.Price,
+		item.Discount,
+	).Scan(&item.OrderItemId)
+
+	if err != nil {
+		return err
+	}
+
+	query = `UPDATE "products" SET "stock" = "stock" - $1, "version" = "version" + 1 WHERE "product_id" = $2;`
+	if _, err := tx.ExecContext(ctx, query, item.Quantity, item.ProductId); err != nil {
+		return err
+	}
+	item.Stock = stock - item.Quantity
+
+	query = `
+		INSERT INTO "inventory_logs" ("product_id", "change", "description")
+		VALUES ($1, $2, $3);
+	`
+	_, err = tx.ExecContext(
+		ctx,
+		query,
+		item.ProductId,
+		fmt.Sprintf("-%d", item.Quantity),
+		fmt.Sprintf("sold in order %s", item.OrderId),
+	)
+
+	return err
+}
+
+func (r *orderRepo) GetOrders() ([]*orders.Order, error) {
+	ords := make([]*orders.Order, 0)
+
+	query := `
+		SELECT "order_id", "customer_id", "total_amount", "payment_method", "status", "order_date"
+		FROM "orders"
+		ORDER BY "order_date" DESC;
+	`
+	err := r.db.Select(&ords, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return ords, nil
+}
+
+func (r *orderRepo) GetOrder(orderId string) (*orders.Order, error) {
+	order := orders.Order{}
+
+	query := `
+		SELECT "order_id", "customer_id", "total_amount", "payment_method", "status", "order_date"
+		FROM "orders"
+		WHERE "order_id" = $1
+		LIMIT 1;
+	`
+	err := r.db.Get(&order, query, orderId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.NotFound("order %s not found", orderId)
+		}
+		return nil, err
+	}
+
+	order.Items = make([]*orders.OrderItem, 0)
+
+	query = `
+		SELECT "order_item_id", "order_id", "product_id", "quantity", "price", "discount"
+		FROM "order_items"
+		WHERE "order_id" = $1
+		ORDER BY "product_id";
+	`
+	err = r.db.Select(&order.Items, query, orderId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+func (r *orderRepo) UpdateOrderStatus(orderId, from, to string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	return setStatus(ctx, r.db, orderId, from, to)
+}
+
+// CancelOrder moves the order to CANCEL and puts every item's quantity
+// back into stock with a matching inventory log, all in one transaction.
+func (r *orderRepo) CancelOrder(orderId, from string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := setStatus(ctx, tx, orderId, from, orders.StatusCancel); err != nil {
+		return err
+	}
+
+	items := make([]*orders.OrderItem, 0)
+
+	query := `
+		SELECT "order_item_id", "order_id", "product_id", "quantity", "price", "discount"
+		FROM "order_items"
+		WHERE "order_id" = $1
+		ORDER BY "product_id";
+	`
+	if err := tx.SelectContext(ctx, &items, query, orderId); err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		query = `UPDATE "products" SET "stock" = "stock" + $1, "version" = "version" + 1 WHERE "product_id" = $2;`
+		if _, err := tx.ExecContext(ctx, query, item.Quantity, item.ProductId); err != nil {
+			return err
+		}
+
+		query = `
+			INSERT INTO "inventory_logs" ("product_id", "change", "description")
+			VALUES ($1, $2, $3);
+		`
+		_, err = tx.ExecContext(
+			ctx,
+			query,
+			item.ProductId,
+			fmt.Sprintf("+%d", item.Quantity),
+			fmt.Sprintf("returned from cancelled order %s", orderId),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// setStatus only updates the order while it still has the status the caller
+// checked, so a concurrent change can't be overwritten.
+func setStatus(ctx context.Context, db sqlx.ExecerContext, orderId, from, to string) error {
+	query := `
+		UPDATE "orders"
+		SET "status" = $1
+		WHERE "order_id" = $2
+		AND "status" = $3;
+	`
+	result, err := db.ExecContext(ctx, query, to, orderId, from)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w: order %s is no longer %s", orders.ErrInvalidTransition, orderId, from)
+	}
+
+	return nil
+}
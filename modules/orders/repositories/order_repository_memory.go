@@ -0,0 +1,170 @@
+package orderrepositories
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/codepnw/sales-api/database"
+	"github.com/codepnw/sales-api/modules/inventories"
+	"github.com/codepnw/sales-api/modules/orders"
+	"github.com/codepnw/sales-api/modules/products"
+	"github.com/codepnw/sales-api/pkg/errs"
+	"github.com/codepnw/sales-api/pkg/utils"
+	"github.com/google/uuid"
+)
+
+type orderMemoryRepo struct {
+	db *database.MemoryDB
+}
+
+func NewOrderMemoryRepository(db *database.MemoryDB) IOrderRepo {
+	return &orderMemoryRepo{db: db}
+}
+
+// CreateOrder follows the PostgreSQL repository, a failed item undoes the
+// whole order including the stock already taken.
+func (r *orderMemoryRepo) CreateOrder(order *orders.Order) (*orders.Order, error) {
+	err := r.db.Update(func(tx *database.MemoryTx) error {
+		if _, ok := tx.Get("customers", order.CustomerId); !ok {
+			return fmt.Errorf("%w: customer %s not found", orders.ErrInvalidOrder, order.CustomerId)
+		}
+		order.OrderId = fmt.Sprintf("O%06d", tx.NextId("orders"))
+
+		var total float64
+		for _, item := range order.Items {
+			item.OrderId = order.OrderId
+
+			row, ok := tx.Get("products", item.ProductId)
+			if !ok {
+				return fmt.Errorf("%w: product %s not found", orders.ErrInvalidOrder, item.ProductId)
+			}
+			product := row.(products.Product)
+
+			if int(product.Stock) < item.Quantity {
+				return fmt.Errorf("%w: product %s has %d left", orders.ErrInsufficientStock, item.ProductId, product.Stock)
+			}
+
+			item.OrderItemId = uuid.NewString()
+			item.Price = product.Price
+			item.Discount = product.Discount
+			tx.Put("order_items", item.OrderItemId, *item)
+
+			product.Stock -= uint(item.Quantity)
+			product.Version++
+			item.Stock = int(product.Stock)
+			tx.Put("products", product.ProductID, product)
+
+			putLog(tx, item.ProductId, fmt.Sprintf("-%d", item.Quantity), fmt.Sprintf("sold in order %s", item.OrderId))
+
+			total += (item.Price - item.Discount) * float64(item.Quantity)
+		}
+		order.TotalAmount = total
+
+		row := *order
+		row.Items = nil
+		tx.Put("orders", order.OrderId, row)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+func (r *orderMemoryRepo) GetOrders() ([]*orders.Order, error) {
+	ords := make([]*orders.Order, 0)
+
+	r.db.View(func(tx *database.MemoryTx) error {
+		for _, row := range tx.Rows("orders") {
+			order := row.(orders.Order)
+			ords = append(ords, &order)
+		}
+		return nil
+	})
+	sort.Slice(ords, func(i, j int) bool { return ords[i].OrderDate.After(ords[j].OrderDate) })
+
+	return ords, nil
+}
+
+func (r *orderMemoryRepo) GetOrder(orderId string) (*orders.Order, error) {
+	order := orders.Order{}
+
+	err := r.db.View(func(tx *database.MemoryTx) error {
+		row, ok := tx.Get("orders", orderId)
+		if !ok {
+			return errs.NotFound("order %s not found", orderId)
+		}
+		order = row.(orders.Order)
+		order.Items = itemsOf(tx, orderId)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+func (r *orderMemoryRepo) UpdateOrderStatus(orderId, from, to string) error {
+	return r.db.Update(func(tx *database.MemoryTx) error {
+		return setMemoryStatus(tx, orderId, from, to)
+	})
+}
+
+func (r *orderMemoryRepo) CancelOrder(orderId, from string) error {
+	return r.db.Update(func(tx *database.MemoryTx) error {
+		if err := setMemoryStatus(tx, orderId, from, orders.StatusCancel); err != nil {
+			return err
+		}
+
+		for _, item := range itemsOf(tx, orderId) {
+			if row, ok := tx.Get("products", item.ProductId); ok {
+				product := row.(products.Product)
+				product.Stock += uint(item.Quantity)
+				product.Version++
+				tx.Put("products", product.ProductID, product)
+			}
+
+			putLog(tx, item.ProductId, fmt.Sprintf("+%d", item.Quantity), fmt.Sprintf("returned from cancelled order %s", orderId))
+		}
+		return nil
+	})
+}
+
+func setMemoryStatus(tx *database.MemoryTx, orderId, from, to string) error {
+	row, ok := tx.Get("orders", orderId)
+	if !ok || row.(orders.Order).Status != from {
+		return fmt.Errorf("%w: order %s is no longer %s", orders.ErrInvalidTransition, orderId, from)
+	}
+
+	order := row.(orders.Order)
+	order.Status = to
+	tx.Put("orders", orderId, order)
+
+	return nil
+}
+
+func itemsOf(tx *database.MemoryTx, orderId string) []*orders.OrderItem {
+	items := make([]*orders.OrderItem, 0)
+	for _, row := range tx.Rows("order_items") {
+		if item := row.(orders.OrderItem); item.OrderId == orderId {
+			items = append(items, &item)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].ProductId < items[j].ProductId })
+
+	return items
+}
+
+func putLog(tx *database.MemoryTx, productId, change, description string) {
+	log := inventories.InventoryLog{
+		InventoryLogId: uuid.NewString(),
+		ProductId:      productId,
+		Change:         change,
+		Description:    description,
+		Date:           utils.LocalTime(),
+		Seq:            int64(tx.NextId("inventory_logs")),
+	}
+	tx.Put("inventory_logs", log.InventoryLogId, log)
+}
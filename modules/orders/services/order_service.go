@@ -0,0 +1,172 @@
+package orderservices
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/codepnw/sales-api/modules/orders"
+	orderrepositories "github.com/codepnw/sales-api/modules/orders/repositories"
+	"github.com/codepnw/sales-api/modules/payments"
+	payrepositories "github.com/codepnw/sales-api/modules/payments/repositories"
+	"github.com/codepnw/sales-api/pkg/errs"
+	"github.com/codepnw/sales-api/pkg/logs"
+	"github.com/codepnw/sales-api/pkg/metrics"
+	"github.com/codepnw/sales-api/pkg/utils"
+)
+
+type IOrderService interface {
+	CreateOrder(ctx context.Context, req *orders.OrderRequest) (*orders.Order, error)
+	GetOrders(ctx context.Context) ([]*orders.Order, error)
+	GetOrder(ctx context.Context, orderId string) (*orders.Order, error)
+	UpdateOrderStatus(ctx context.Context, orderId string, req *orders.OrderStatusRequest) (*orders.Order, error)
+}
+
+type orderService struct {
+	repo     orderrepositories.IOrderRepo
+	payments payrepositories.IPaymentRepo
+}
+
+func NewOrderService(repo orderrepositories.IOrderRepo, payRepo payrepositories.IPaymentRepo) IOrderService {
+	return &orderService{repo: repo, payments: payRepo}
+}
+
+func (s *orderService) CreateOrder(ctx context.Context, req *orders.OrderRequest) (*orders.Order, error) {
+	// merge lines of the same product so stock is checked once per product
+	items := make([]*orders.OrderItem, 0, len(req.Items))
+	byProduct := make(map[string]*orders.OrderItem)
+
+	for _, item := range req.Items {
+		if existing, ok := byProduct[item.ProductId]; ok {
+			existing.Quantity += item.Quantity
+			continue
+		}
+
+		orderItem := &orders.OrderItem{ProductId: item.ProductId, Quantity: item.Quantity}
+		byProduct[item.ProductId] = orderItem
+		items = append(items, orderItem)
+	}
+
+	order := orders.Order{
+		CustomerId:    req.CustomerId,
+		PaymentMethod: req.PaymentMethod,
+		Status:        orders.StatusWaiting,
+		OrderDate:     utils.LocalTime(),
+		Items:         items,
+	}
+
+	o, err := s.repo.CreateOrder(&order)
+	if err != nil {
+		logs.ErrorContext(ctx, err)
+		if errors.Is(err, orders.ErrInsufficientStock) {
+			metrics.StockRejections.WithLabelValues("order").Inc()
+		}
+		if errs.Known(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed create order")
+	}
+	metrics.OrdersCreated.WithLabelValues(o.PaymentMethod).Inc()
+	// items are merged per product, so each sold out product counts once
+	for _, item := range o.Items {
+		if item.Stock == 0 {
+			metrics.ProductsOutOfStock.WithLabelValues("order").Inc()
+		}
+	}
+
+	return o, nil
+}
+
+func (s *orderService) GetOrders(ctx context.Context) ([]*orders.Order, error) {
+	o, err := s.repo.GetOrders()
+	if err != nil {
+		logs.ErrorContext(ctx, err)
+		return nil, fmt.Errorf("failed get orders")
+	}
+
+	return o, nil
+}
+
+func (s *orderService) GetOrder(ctx context.Context, orderId string) (*orders.Order, error) {
+	o, err := s.repo.GetOrder(orderId)
+	if err != nil {
+		logs.ErrorContext(ctx, err)
+		if errs.Known(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed get order")
+	}
+
+	return o, nil
+}
+
+func (s *orderService) UpdateOrderStatus(ctx context.Context, orderId string, req *orders.OrderStatusRequest) (*orders.Order, error) {
+	status := strings.ToUpper(strings.TrimSpace(req.Status))
+
+	o, err := s.repo.GetOrder(orderId)
+	if err != nil {
+		logs.ErrorContext(ctx, err)
+		if errs.Known(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed get order")
+	}
+
+	if !orders.CanTransition(o.Status, status) {
+		return nil, fmt.Errorf("%w: cannot change order from %s to %s", orders.ErrInvalidTransition, o.Status, req.Status)
+	}
+
+	if status == orders.StatusCompleted {
+		balance, err := s.balance(ctx, orderId)
+		if err != nil {
+			return nil, err
+		}
+		if !balance.IsPaid() {
+			return nil, fmt.Errorf("%w: order %s has %.2f outstanding", orders.ErrInvalidTransition, orderId, balance.Outstanding)
+		}
+	}
+
+	if status == orders.StatusCancel {
+		err = s.repo.CancelOrder(orderId, o.Status)
+	} else {
+		err = s.repo.UpdateOrderStatus(orderId, o.Status, status)
+	}
+	if err != nil {
+		logs.ErrorContext(ctx, err)
+		if errs.Known(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed update order status")
+	}
+
+	o, err = s.GetOrder(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
+
+	// a cancelled order takes no more payments, so whatever was paid so far
+	// is what has to be given back
+	if status == orders.StatusCancel {
+		balance, err := s.balance(ctx, orderId)
+		if err != nil {
+			return nil, err
+		}
+		o.RefundDue = balance.PaidAmount
+	}
+
+	return o, nil
+}
+
+func (s *orderService) balance(ctx context.Context, orderId string) (*payments.Balance, error) {
+	balance, err := s.payments.GetBalance(orderId)
+	if err != nil {
+		logs.ErrorContext(ctx, err)
+		if errs.Known(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed get order balance")
+	}
+
+	return balance, nil
+}
@@ -0,0 +1,69 @@
+package payhandlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/codepnw/sales-api/modules/payments"
+	payservices "github.com/codepnw/sales-api/modules/payments/services"
+	"github.com/codepnw/sales-api/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type paymentHandler struct {
+	service payservices.IPaymentService
+}
+
+func NewPaymentHandler(service payservices.IPaymentService) *paymentHandler {
+	return &paymentHandler{service: service}
+}
+
+type paymentErr string
+
+const (
+	createError     paymentErr = "payments-001"
+	getAllError     paymentErr = "payments-002"
+	getBalanceError paymentErr = "payments-003"
+)
+
+func (h *paymentHandler) CreatePayment(c *gin.Context) {
+	orderId := strings.Trim(c.Param("orderId"), " ")
+	request := payments.PaymentRequest{}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.NewResponse(c).BindError(string(createError), err)
+		return
+	}
+
+	payment, err := h.service.CreatePayment(c.Request.Context(), orderId, &request)
+	if err != nil {
+		utils.NewResponse(c).Fail(string(createError), err)
+		return
+	}
+
+	utils.NewResponse(c).Success(http.StatusCreated, payment)
+}
+
+func (h *paymentHandler) GetPayments(c *gin.Context) {
+	orderId := strings.Trim(c.Param("orderId"), " ")
+
+	payments, err := h.service.GetPayments(c.Request.Context(), orderId)
+	if err != nil {
+		utils.NewResponse(c).Fail(string(getAllError), err)
+		return
+	}
+
+	utils.NewResponse(c).Success(http.StatusOK, payments)
+}
+
+func (h *paymentHandler) GetBalance(c *gin.Context) {
+	orderId := strings.Trim(c.Param("orderId"), " ")
+
+	balance, err := h.service.GetBalance(c.Request.Context(), orderId)
+	if err != nil {
+		utils.NewResponse(c).Fail(string(getBalanceError), err)
+		return
+	}
+
+	utils.NewResponse(c).Success(http.StatusOK, balance)
+}
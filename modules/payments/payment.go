@@ -0,0 +1,78 @@
+package payments
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/codepnw/sales-api/modules/orders"
+	"github.com/codepnw/sales-api/pkg/errs"
+)
+
+var (
+	ErrOrderNotPayable = errs.Conflict("order is not payable")
+	ErrOverpayment     = errs.Conflict("payment exceeds outstanding balance")
+)
+
+type Payment struct {
+	PaymentId     string    `db:"payment_id" json:"paymentId"`
+	OrderId       string    `db:"order_id" json:"orderId"`
+	Amount        float64   `db:"amount" json:"amount"`
+	PaymentMethod string    `db:"payment_method" json:"paymentMethod"`
+	PaymentDate   time.Time `db:"payment_date" json:"paymentDate"`
+	Change        float64   `db:"-" json:"change"`
+}
+
+type PaymentRequest struct {
+	Amount        float64 `json:"amount" form:"amount" binding:"gt=0"`
+	PaymentMethod string  `json:"paymentMethod" form:"payment_method" binding:"required,oneof=CASH TRANSFER ETC"`
+}
+
+type Balance struct {
+	OrderId     string  `db:"order_id" json:"orderId"`
+	Status      string  `db:"status" json:"status"`
+	TotalAmount float64 `db:"total_amount" json:"totalAmount"`
+	PaidAmount  float64 `db:"paid_amount" json:"paidAmount"`
+	Outstanding float64 `db:"-" json:"outstanding"`
+}
+
+func roundMoney(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+func (b *Balance) Calculate() {
+	b.Outstanding = roundMoney(b.TotalAmount - b.PaidAmount)
+}
+
+// Settle applies the payment to the balance. Cash tendered above the
+// outstanding amount is recorded as the outstanding amount and the rest is
+// returned as change, any other method must not pay more than is owed.
+func (b *Balance) Settle(payment *Payment) error {
+	b.Calculate()
+
+	if b.Status != orders.StatusWaiting {
+		return fmt.Errorf("%w: order %s is %s", ErrOrderNotPayable, b.OrderId, b.Status)
+	}
+	if b.Outstanding <= 0 {
+		return fmt.Errorf("%w: order %s is already paid", ErrOrderNotPayable, b.OrderId)
+	}
+
+	amount := roundMoney(payment.Amount)
+	if amount > b.Outstanding {
+		if payment.PaymentMethod != orders.PaymentCash {
+			return fmt.Errorf("%w: outstanding is %.2f", ErrOverpayment, b.Outstanding)
+		}
+		payment.Change = roundMoney(amount - b.Outstanding)
+		amount = b.Outstanding
+	}
+
+	payment.Amount = amount
+	b.PaidAmount = roundMoney(b.PaidAmount + amount)
+	b.Calculate()
+
+	return nil
+}
+
+func (b *Balance) IsPaid() bool {
+	return b.Outstanding <= 0
+}
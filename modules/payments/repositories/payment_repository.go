@@ -0,0 +1,161 @@
+package payrepositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/codepnw/sales-api/database"
+	"github.com/codepnw/sales-api/modules/orders"
+	"github.com/codepnw/sales-api/modules/payments"
+	"github.com/codepnw/sales-api/pkg/errs"
+	"github.com/jmoiron/sqlx"
+)
+
+var Schemas = []database.TableSchema{
+	{
+		Table: "payments",
+		Columns: map[string]string{
+			"payment_id":     database.TypeUUID,
+			"order_id":       database.TypeVarchar,
+			"amount":         database.TypeFloat,
+			"payment_method": database.TypeEnum,
+			"payment_date":   database.TypeTimestamp,
+		},
+	},
+	{
+		Table: "orders",
+		Columns: map[string]string{
+			"order_id":     database.TypeVarchar,
+			"status":       database.TypeEnum,
+			"total_amount": database.TypeFloat,
+		},
+	},
+}
+
+type IPaymentRepo interface {
+	CreatePayment(payment *payments.Payment) (*payments.Payment, error)
+	GetPayments(orderId string) ([]*payments.Payment, error)
+	GetBalance(orderId string) (*payments.Balance, error)
+}
+
+type paymentRepo struct {
+	db *sqlx.DB
+}
+
+func NewPaymentRepository(db *sqlx.DB) IPaymentRepo {
+	return &paymentRepo{db: db}
+}
+
+// CreatePayment locks the order, settles the payment against what is still
+// owed and completes the order once it is fully paid.
+func (r *paymentRepo) CreatePayment(payment *payments.Payment) (*payments.Payment, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	balance := payments.Balance{}
+
+	query := fmt.Sprintf(`
+		SELECT "order_id", "status", "total_amount"
+		FROM "orders"
+		WHERE "order_id" = $1
+		%s;
+	`, database.ForUpdate(tx))
+	err = tx.GetContext(ctx, &balance, query, payment.OrderId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.NotFound("order %s not found", payment.OrderId)
+		}
+		return nil, err
+	}
+
+	query = `SELECT COALESCE(SUM("amount"), 0) FROM "payments" WHERE "order_id" = $1;`
+	if err := tx.GetContext(ctx, &balance.PaidAmount, query, payment.OrderId); err != nil {
+		return nil, err
+	}
+
+	if err := balance.Settle(payment); err != nil {
+		return nil, err
+	}
+
+	query = `
+		INSERT INTO "payments" ("order_id", "amount", "payment_method", "payment_date")
+		VALUES ($1, $2, $3, $4)
+		RETURNING "payment_id";
+	`
+	err = tx.QueryRowContext(
+		ctx,
+		query,
+		payment.OrderId,
+		payment.Amount,
+		payment.PaymentMethod,
+		payment.PaymentDate,
+	).Scan(&payment.PaymentId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if balance.IsPaid() {
+		query = `UPDATE "orders" SET "status" = $1 WHERE "order_id" = $2;`
+		if _, err := tx.ExecContext(ctx, query, orders.StatusCompleted, payment.OrderId); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+func (r *paymentRepo) GetPayments(orderId string) ([]*payments.Payment, error) {
+	pays := make([]*payments.Payment, 0)
+
+	query := `
+		SELECT "payment_id", "order_id", "amount", "payment_method", "payment_date"
+		FROM "payments"
+		WHERE "order_id" = $1
+		ORDER BY "payment_date";
+	`
+	err := r.db.Select(&pays, query, orderId)
+	if err != nil {
+		return nil, err
+	}
+
+	return pays, nil
+}
+
+func (r *paymentRepo) GetBalance(orderId string) (*payments.Balance, error) {
+	balance := payments.Balance{}
+
+	query := `
+		SELECT
+			o."order_id",
+			o."status",
+			o."total_amount",
+			COALESCE(SUM(p."amount"), 0) AS "paid_amount"
+		FROM "orders" o
+		LEFT JOIN "payments" p ON p."order_id" = o."order_id"
+		WHERE o."order_id" = $1
+		GROUP BY o."order_id";
+	`
+	err := r.db.Get(&balance, query, orderId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.NotFound("order %s not found", orderId)
+		}
+		return nil, err
+	}
+	balance.Calculate()
+
+	return &balance, nil
+}
@@ -0,0 +1,103 @@
+package payrepositories
+
+import (
+	"sort"
+
+	"github.com/codepnw/sales-api/database"
+	"github.com/codepnw/sales-api/modules/orders"
+	"github.com/codepnw/sales-api/modules/payments"
+	"github.com/codepnw/sales-api/pkg/errs"
+	"github.com/google/uuid"
+)
+
+type paymentMemoryRepo struct {
+	db *database.MemoryDB
+}
+
+func NewPaymentMemoryRepository(db *database.MemoryDB) IPaymentRepo {
+	return &paymentMemoryRepo{db: db}
+}
+
+func (r *paymentMemoryRepo) CreatePayment(payment *payments.Payment) (*payments.Payment, error) {
+	err := r.db.Update(func(tx *database.MemoryTx) error {
+		balance, err := balanceOf(tx, payment.OrderId)
+		if err != nil {
+			return err
+		}
+
+		if err := balance.Settle(payment); err != nil {
+			return err
+		}
+
+		payment.PaymentId = uuid.NewString()
+		row := *payment
+		row.Change = 0
+		tx.Put("payments", payment.PaymentId, row)
+
+		if balance.IsPaid() {
+			orderRow, _ := tx.Get("orders", payment.OrderId)
+			order := orderRow.(orders.Order)
+			order.Status = orders.StatusCompleted
+			tx.Put("orders", order.OrderId, order)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+func (r *paymentMemoryRepo) GetPayments(orderId string) ([]*payments.Payment, error) {
+	pays := make([]*payments.Payment, 0)
+
+	r.db.View(func(tx *database.MemoryTx) error {
+		for _, row := range tx.Rows("payments") {
+			if pay := row.(payments.Payment); pay.OrderId == orderId {
+				pays = append(pays, &pay)
+			}
+		}
+		return nil
+	})
+	sort.Slice(pays, func(i, j int) bool { return pays[i].PaymentDate.Before(pays[j].PaymentDate) })
+
+	return pays, nil
+}
+
+func (r *paymentMemoryRepo) GetBalance(orderId string) (*payments.Balance, error) {
+	var balance *payments.Balance
+
+	err := r.db.View(func(tx *database.MemoryTx) error {
+		b, err := balanceOf(tx, orderId)
+		balance = b
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	balance.Calculate()
+
+	return balance, nil
+}
+
+func balanceOf(tx *database.MemoryTx, orderId string) (*payments.Balance, error) {
+	row, ok := tx.Get("orders", orderId)
+	if !ok {
+		return nil, errs.NotFound("order %s not found", orderId)
+	}
+	order := row.(orders.Order)
+
+	balance := &payments.Balance{
+		OrderId:     order.OrderId,
+		Status:      order.Status,
+		TotalAmount: order.TotalAmount,
+	}
+	for _, row := range tx.Rows("payments") {
+		if pay := row.(payments.Payment); pay.OrderId == orderId {
+			balance.PaidAmount += pay.Amount
+		}
+	}
+
+	return balance, nil
+}
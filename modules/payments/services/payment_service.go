@@ -0,0 +1,69 @@
+package payservices
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/codepnw/sales-api/modules/payments"
+	payrepositories "github.com/codepnw/sales-api/modules/payments/repositories"
+	"github.com/codepnw/sales-api/pkg/errs"
+	"github.com/codepnw/sales-api/pkg/logs"
+	"github.com/codepnw/sales-api/pkg/utils"
+)
+
+type IPaymentService interface {
+	CreatePayment(ctx context.Context, orderId string, req *payments.PaymentRequest) (*payments.Payment, error)
+	GetPayments(ctx context.Context, orderId string) ([]*payments.Payment, error)
+	GetBalance(ctx context.Context, orderId string) (*payments.Balance, error)
+}
+
+type paymentService struct {
+	repo payrepositories.IPaymentRepo
+}
+
+func NewPaymentService(repo payrepositories.IPaymentRepo) IPaymentService {
+	return &paymentService{repo: repo}
+}
+
+func (s *paymentService) CreatePayment(ctx context.Context, orderId string, req *payments.PaymentRequest) (*payments.Payment, error) {
+	payment := payments.Payment{
+		OrderId:       orderId,
+		Amount:        req.Amount,
+		PaymentMethod: req.PaymentMethod,
+		PaymentDate:   utils.LocalTime(),
+	}
+
+	p, err := s.repo.CreatePayment(&payment)
+	if err != nil {
+		logs.ErrorContext(ctx, err)
+		if errs.Known(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed create payment")
+	}
+
+	return p, nil
+}
+
+func (s *paymentService) GetPayments(ctx context.Context, orderId string) ([]*payments.Payment, error) {
+	p, err := s.repo.GetPayments(orderId)
+	if err != nil {
+		logs.ErrorContext(ctx, err)
+		return nil, fmt.Errorf("failed get payments")
+	}
+
+	return p, nil
+}
+
+func (s *paymentService) GetBalance(ctx context.Context, orderId string) (*payments.Balance, error) {
+	b, err := s.repo.GetBalance(orderId)
+	if err != nil {
+		logs.ErrorContext(ctx, err)
+		if errs.Known(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed get balance")
+	}
+
+	return b, nil
+}
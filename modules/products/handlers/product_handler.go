@@ -21,104 +21,165 @@ func NewProductHandler(service prodservices.IProductService) *productHandler {
 type productErr string
 
 const (
-	createError productErr = "products-001"
-	getOneError productErr = "products-002"
-	getAllError productErr = "products-003"
-	updateError productErr = "products-004"
-	deleteError productErr = "products-005"
+	createError  productErr = "products-001"
+	getOneError  productErr = "products-002"
+	getAllError  productErr = "products-003"
+	updateError  productErr = "products-004"
+	deleteError  productErr = "products-005"
+	searchError  productErr = "products-006"
+	replaceError productErr = "products-007"
 )
 
 func (h *productHandler) CreateProduct(c *gin.Context) {
 	request := products.ProductRequest{}
 
 	if err := c.ShouldBindJSON(&request); err != nil {
-		utils.NewResponse(c).Error(
-			http.StatusBadRequest,
-			string(createError),
-			err.Error(),
-		)
+		utils.NewResponse(c).BindError(string(createError), err)
 		return
 	}
 
-	product, err := h.service.CreateProduct(&request)
+	product, err := h.service.CreateProduct(c.Request.Context(), &request)
 	if err != nil {
-		utils.NewResponse(c).Error(
-			http.StatusInternalServerError,
-			string(createError),
-			err.Error(),
-		)
+		utils.NewResponse(c).Fail(string(createError), err)
 		return
 	}
 
+	c.Header("ETag", utils.ETag(product.Version))
 	utils.NewResponse(c).Success(http.StatusCreated, product)
 }
 
 func (h *productHandler) GetProducts(c *gin.Context) {
-	products, err := h.service.GetProducts()
+	filter := products.ProductFilter{}
+
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		utils.NewResponse(c).BindError(string(getAllError), err)
+		return
+	}
+
+	products, pagination, err := h.service.GetProducts(c.Request.Context(), &filter)
 	if err != nil {
-		utils.NewResponse(c).Error(
-			http.StatusInternalServerError,
-			string(getAllError),
-			err.Error(),
-		)
+		utils.NewResponse(c).Fail(string(getAllError), err)
 		return
 	}
 
-	utils.NewResponse(c).Success(http.StatusOK, products)
+	utils.NewResponse(c).SuccessWithPagination(http.StatusOK, products, pagination)
 }
 
+func (h *productHandler) SearchProducts(c *gin.Context) {
+	search := products.ProductSearch{}
+
+	if err := c.ShouldBindQuery(&search); err != nil {
+		utils.NewResponse(c).BindError(string(searchError), err)
+		return
+	}
+
+	products, pagination, err := h.service.SearchProducts(c.Request.Context(), &search)
+	if err != nil {
+		utils.NewResponse(c).Fail(string(searchError), err)
+		return
+	}
+
+	utils.NewResponse(c).SuccessWithPagination(http.StatusOK, products, pagination)
+}
+
+// GetProduct sends the version as the ETag, a client holding the same one in
+// If-None-Match gets a 304 and sends it back in If-Match when writing.
 func (h *productHandler) GetProduct(c *gin.Context) {
 	id := c.Param("productId")
 
-	product, err := h.service.GetProduct(id)
+	product, err := h.service.GetProduct(c.Request.Context(), id)
 	if err != nil {
-		utils.NewResponse(c).Error(
-			http.StatusInternalServerError,
-			string(getOneError),
-			err.Error(),
-		)
+		utils.NewResponse(c).Fail(string(getOneError), err)
+		return
+	}
+
+	etag := utils.ETag(product.Version)
+	c.Header("ETag", etag)
+	if utils.NotModified(c, etag) {
+		c.Status(http.StatusNotModified)
 		return
 	}
 
 	utils.NewResponse(c).Success(http.StatusOK, product)
 }
 
+// UpdateProduct merges the body into the current product, see
+// utils.ShouldBindMergePatch, so a field can be set to 0 or "" on purpose.
+// Like PUT and DELETE it needs the ETag of the product in If-Match.
 func (h *productHandler) UpdateProduct(c *gin.Context) {
 	id := strings.Trim(c.Param("productId"), " ")
+
+	version, err := utils.IfMatch(c)
+	if err != nil {
+		utils.NewResponse(c).Fail(string(updateError), err)
+		return
+	}
+
+	current, err := h.service.GetProduct(c.Request.Context(), id)
+	if err != nil {
+		utils.NewResponse(c).Fail(string(updateError), err)
+		return
+	}
+	// the patch was merged into this version, it must still be there on write
+	if version == utils.AnyVersion {
+		version = current.Version
+	}
+
+	request := current.Request()
+	if err := utils.ShouldBindMergePatch(c, &request); err != nil {
+		utils.NewResponse(c).BindError(string(updateError), err)
+		return
+	}
+
+	p, err := h.service.UpdateProduct(c.Request.Context(), id, version, &request)
+	if err != nil {
+		utils.NewResponse(c).Fail(string(updateError), err)
+		return
+	}
+
+	c.Header("ETag", utils.ETag(p.Version))
+	utils.NewResponse(c).Success(http.StatusOK, &p)
+}
+
+// ReplaceProduct writes every field of the body, a field left out is stored
+// as its zero value. Stock is kept, sending it is a 422.
+func (h *productHandler) ReplaceProduct(c *gin.Context) {
+	id := strings.Trim(c.Param("productId"), " ")
 	request := products.ProductRequest{}
 
+	version, err := utils.IfMatch(c)
+	if err != nil {
+		utils.NewResponse(c).Fail(string(replaceError), err)
+		return
+	}
+
 	if err := c.ShouldBindJSON(&request); err != nil {
-		utils.NewResponse(c).Error(
-			http.StatusBadRequest,
-			string(updateError),
-			err.Error(),
-		)
+		utils.NewResponse(c).BindError(string(replaceError), err)
 		return
 	}
 
-	p, err := h.service.UpdateProduct(id, &request)
+	p, err := h.service.UpdateProduct(c.Request.Context(), id, version, &request)
 	if err != nil {
-		utils.NewResponse(c).Error(
-			http.StatusInternalServerError,
-			string(updateError),
-			err.Error(),
-		)
+		utils.NewResponse(c).Fail(string(replaceError), err)
 		return
 	}
 
+	c.Header("ETag", utils.ETag(p.Version))
 	utils.NewResponse(c).Success(http.StatusOK, &p)
 }
 
 func (h *productHandler) DeleteProduct(c *gin.Context) {
 	id := strings.Trim(c.Param("productId"), " ")
 
-	err := h.service.DeleteProduct(id)
+	version, err := utils.IfMatch(c)
+	if err != nil {
+		utils.NewResponse(c).Fail(string(deleteError), err)
+		return
+	}
+
+	err = h.service.DeleteProduct(c.Request.Context(), id, version)
 	if err != nil {
-		utils.NewResponse(c).Error(
-			http.StatusInternalServerError,
-			string(deleteError),
-			err.Error(),
-		)
+		utils.NewResponse(c).Fail(string(deleteError), err)
 		return
 	}
 
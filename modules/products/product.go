@@ -1,24 +1,88 @@
 package products
 
-import "time"
+import (
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/codepnw/sales-api/pkg/utils"
+)
 
 type Product struct {
-	ProductID  string     `db:"product_id" json:"productId"`
-	Name       string     `db:"name" json:"name"`
-	Desc       string     `db:"desc" json:"desc"`
-	Price      float64    `db:"price" json:"price"`
-	Discount   uint       `db:"discount" json:"discount"`
-	Stock      uint       `db:"stock" json:"stock"`
-	CategoryID uint       `db:"category_id" json:"categoryId"`
+	ProductID  string    `db:"product_id" json:"productId"`
+	Name       string    `db:"name" json:"name"`
+	Desc       string    `db:"desc" json:"desc"`
+	Price      float64   `db:"price" json:"price"`
+	Discount   float64   `db:"discount" json:"discount"`
+	Stock      uint      `db:"stock" json:"stock"`
+	CategoryID uint      `db:"category_id" json:"categoryId"`
+	Version    int       `db:"version" json:"version"`
 	CreatedAt  time.Time `db:"created_at" json:"createdAt"`
-	UpdatedAt  time.Time  `db:"updated_at" json:"updatedAt"`
+	UpdatedAt  time.Time `db:"updated_at" json:"updatedAt"`
 }
 
+// ProductRequest creates or replaces a product, a discount above the price
+// or a category that doesn't exist is rejected with the field that caused it.
+// Stock is only taken on create, afterwards it changes through a stock
+// adjustment so every change has an inventory log.
 type ProductRequest struct {
-	Name       string  `json:"name" form:"name"`
-	Desc       string  `json:"desc" form:"desc"`
-	Price      float64 `json:"price" form:"price"`
-	Discount   uint    `json:"discount" form:"discount"`
-	Stock      uint    `json:"stock" form:"stock"`
-	CategoryID uint    `json:"categoryId" form:"category_id"`
+	Name       string  `json:"name" form:"name" binding:"required,max=255"`
+	Desc       string  `json:"desc" form:"desc" binding:"max=255"`
+	Price      float64 `json:"price" form:"price" binding:"gt=0"`
+	Discount   float64 `json:"discount" form:"discount" binding:"gte=0,ltefield=Price"`
+	Stock      *uint   `json:"stock" form:"stock"`
+	CategoryID uint    `json:"categoryId" form:"category_id" binding:"required"`
+}
+
+// Request returns the fields of p a client may change, PATCH merges its
+// body into them and writes the result like a PUT. Stock is left out, a
+// patch that sets it is rejected.
+func (p *Product) Request() ProductRequest {
+	return ProductRequest{
+		Name:       p.Name,
+		Desc:       p.Desc,
+		Price:      p.Price,
+		Discount:   p.Discount,
+		CategoryID: p.CategoryID,
+	}
+}
+
+type ProductFilter struct {
+	utils.PageQuery
+	Sort       string   `form:"sort"`
+	Order      string   `form:"order"`
+	CategoryID *uint    `form:"categoryId"`
+	MinPrice   *float64 `form:"minPrice"`
+	MaxPrice   *float64 `form:"maxPrice"`
+	InStock    bool     `form:"inStock"`
+}
+
+type ProductSearch struct {
+	utils.PageQuery
+	Q string `form:"q"`
+}
+
+// Terms splits q into lower case words, dropping anything that isn't a letter
+// or digit. Combining marks stay in the word, Thai writes vowels and tone
+// marks that way and "ชาเขียว" would otherwise split after "ชาเข".
+func (s *ProductSearch) Terms() []string {
+	words := strings.FieldsFunc(s.Q, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r) && !unicode.IsMark(r)
+	})
+
+	terms := make([]string, 0, len(words))
+	for _, w := range words {
+		terms = append(terms, strings.ToLower(w))
+	}
+	return terms
+}
+
+// TsQuery turns q into a prefix tsquery, "cof mil" becomes "cof:* & mil:*",
+// so partial names match while the cashier is still typing.
+func (s *ProductSearch) TsQuery() string {
+	terms := s.Terms()
+	for i, t := range terms {
+		terms[i] = t + ":*"
+	}
+	return strings.Join(terms, " & ")
 }
@@ -4,18 +4,42 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"time"
+	"strings"
 
+	"github.com/codepnw/sales-api/database"
 	"github.com/codepnw/sales-api/modules/products"
+	"github.com/codepnw/sales-api/pkg/errs"
 	"github.com/jmoiron/sqlx"
 )
 
+var Schemas = []database.TableSchema{
+	{
+		Table: "products",
+		Columns: map[string]string{
+			"product_id":    database.TypeVarchar,
+			"name":          database.TypeVarchar,
+			"desc":          database.TypeVarchar,
+			"price":         database.TypeFloat,
+			"discount":      database.TypeFloat,
+			"stock":         database.TypeInt,
+			"category_id":   database.TypeInt,
+			"created_at":    database.TypeTimestamp,
+			"updated_at":    database.TypeTimestamp,
+			"version":       database.TypeInt,
+			"search_vector": database.TypeTsvector,
+		},
+	},
+}
+
 type IProductRepo interface {
-	CreateProduct(product *products.Product) (*products.Product, error)
-	GetProducts() ([]*products.Product, error)
-	GetProduct(productID string) (*products.Product, error)
-	UpdateProduct(product *products.Product) (*products.Product, error)
-	DeleteProduct(productID string) error
+	CreateProduct(ctx context.Context, product *products.Product) (*products.Product, error)
+	GetProducts(ctx context.Context, filter *products.ProductFilter) ([]*products.Product, int, error)
+	SearchProducts(ctx context.Context, search *products.ProductSearch) ([]*products.Product, int, error)
+	GetProduct(ctx context.Context, productID string) (*products.Product, error)
+	// UpdateProduct and DeleteProduct only touch the row while its version
+	// is still the one given, version 0 skips the check.
+	UpdateProduct(ctx context.Context, product *products.Product) (*products.Product, error)
+	DeleteProduct(ctx context.Context, productID string, version int) error
 }
 
 type productRepo struct {
@@ -26,13 +50,13 @@ func NewProductRepository(db *sqlx.DB) IProductRepo {
 	return &productRepo{db: db}
 }
 
-func (r *productRepo) CreateProduct(product *products.Product) (*products.Product, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+func (r *productRepo) CreateProduct(ctx context.Context, product *products.Product) (*products.Product, error) {
+	ctx, cancel := database.WithTimeout(ctx)
 	defer cancel()
 
 	query := `
-		INSERT INTO products ("name", "desc", "price", "discount", "stock", "category_id", "created_at", "updated_at")
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO products ("name", "desc", "price", "discount", "stock", "category_id", "version", "created_at", "updated_at")
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 		RETURNING "product_id";
 	`
 	err := r.db.QueryRowContext(
@@ -44,45 +68,142 @@ func (r *productRepo) CreateProduct(product *products.Product) (*products.Produc
 		product.Discount,
 		product.Stock,
 		product.CategoryID,
+		product.Version,
 		product.CreatedAt,
 		product.UpdatedAt,
 	).Scan(&product.ProductID)
 
 	if err != nil {
+		if database.IsForeignKeyViolation(err) {
+			return nil, errs.InvalidField("categoryId", "category %d does not exist", product.CategoryID)
+		}
 		return nil, err
 	}
 
 	return product, nil
 }
 
-func (r *productRepo) GetProducts() ([]*products.Product, error) {
+// sortColumns whitelists the columns GetProducts can order by.
+var sortColumns = map[string]string{
+	"name":       "name",
+	"price":      "price",
+	"created_at": "created_at",
+	"createdAt":  "created_at",
+}
+
+func sortOrder(filter *products.ProductFilter) (string, string) {
+	column, ok := sortColumns[filter.Sort]
+	if !ok {
+		column = "created_at"
+	}
+
+	if strings.EqualFold(filter.Order, "desc") {
+		return column, "DESC"
+	}
+	return column, "ASC"
+}
+
+func (r *productRepo) GetProducts(ctx context.Context, filter *products.ProductFilter) ([]*products.Product, int, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+
+	prods := make([]*products.Product, 0)
+	conditions := make([]string, 0)
+	args := make([]any, 0)
+
+	if filter.CategoryID != nil {
+		args = append(args, *filter.CategoryID)
+		conditions = append(conditions, fmt.Sprintf(`"category_id" = $%d`, len(args)))
+	}
+	if filter.MinPrice != nil {
+		args = append(args, *filter.MinPrice)
+		conditions = append(conditions, fmt.Sprintf(`"price" >= $%d`, len(args)))
+	}
+	if filter.MaxPrice != nil {
+		args = append(args, *filter.MaxPrice)
+		conditions = append(conditions, fmt.Sprintf(`"price" <= $%d`, len(args)))
+	}
+	if filter.InStock {
+		conditions = append(conditions, `"stock" > 0`)
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM "products" %s;`, where)
+	if err := r.db.GetContext(ctx, &total, query, args...); err != nil {
+		return nil, 0, err
+	}
+
+	orderBy, direction := sortOrder(filter)
+
+	args = append(args, filter.Limit, filter.Offset())
+	query = fmt.Sprintf(`
+		SELECT "product_id", "name", "desc", "price", "discount", "stock", "category_id", "version", "created_at", "updated_at"
+		FROM "products"
+		%s
+		ORDER BY "%s" %s, "product_id" %s
+		LIMIT $%d OFFSET $%d;
+	`, where, orderBy, direction, direction, len(args)-1, len(args))
+
+	err := r.db.SelectContext(ctx, &prods, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return prods, total, nil
+}
+
+func (r *productRepo) SearchProducts(ctx context.Context, search *products.ProductSearch) ([]*products.Product, int, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+
 	prods := make([]*products.Product, 0)
+	tsQuery := search.TsQuery()
 
+	var total int
 	query := `
-		SELECT "product_id", "name", "desc", "price", "discount", "stock", "category_id", "created_at", "updated_at"
-		FROM "products";
+		SELECT COUNT(*) FROM "products"
+		WHERE "search_vector" @@ to_tsquery('simple', $1);
 	`
-	err := r.db.Select(&prods, query)
+	if err := r.db.GetContext(ctx, &total, query, tsQuery); err != nil {
+		return nil, 0, err
+	}
+
+	query = `
+		SELECT "product_id", "name", "desc", "price", "discount", "stock", "category_id", "version", "created_at", "updated_at"
+		FROM "products", to_tsquery('simple', $1) AS "q"
+		WHERE "search_vector" @@ "q"
+		ORDER BY ts_rank("search_vector", "q") DESC, "name", "product_id"
+		LIMIT $2 OFFSET $3;
+	`
+	err := r.db.SelectContext(ctx, &prods, query, tsQuery, search.Limit, search.Offset())
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
-	return prods, nil
+	return prods, total, nil
 }
 
-func (r *productRepo) GetProduct(productID string) (*products.Product, error) {
+func (r *productRepo) GetProduct(ctx context.Context, productID string) (*products.Product, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+
 	prod := products.Product{}
 
 	query := `
-		SELECT "product_id", "name", "desc", "price", "discount", "stock", "category_id", "created_at", "updated_at"
+		SELECT "product_id", "name", "desc", "price", "discount", "stock", "category_id", "version", "created_at", "updated_at"
 		FROM "products"
 		WHERE "product_id" = $1
 		LIMIT 1;
 	`
-	err := r.db.Get(&prod, query, productID)
+	err := r.db.GetContext(ctx, &prod, query, productID)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("product_id not found")
+			return nil, errs.NotFound("product %s not found", productID)
 		}
 		return nil, err
 	}
@@ -90,34 +211,51 @@ func (r *productRepo) GetProduct(productID string) (*products.Product, error) {
 	return &prod, nil
 }
 
-func (r *productRepo) UpdateProduct(product *products.Product) (*products.Product, error) {
+func (r *productRepo) UpdateProduct(ctx context.Context, product *products.Product) (*products.Product, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+
 	query := `
 		UPDATE "products"
 		SET 
-			"name" = COALESCE(NULLIF($1, ''), "name"),
-			"desc" = COALESCE(NULLIF($2, ''), "desc"),
-			"price" = COALESCE(NULLIF($3, 0.0), "price"),
-			"discount" = COALESCE(NULLIF($4, 0), "discount"),
-			"stock" = COALESCE(NULLIF($5, 0), "stock"),
-			"updated_at" = $6
-		WHERE "product_id" = $7;
+			"name" = $1,
+			"desc" = $2,
+			"price" = $3,
+			"discount" = $4,
+			"category_id" = $5,
+			"updated_at" = $6,
+			"version" = "version" + 1
+		WHERE "product_id" = $7
+		AND ($8 = 0 OR "version" = $8);
 	`
-	_, err := r.db.ExecContext(
-		context.Background(),
+	result, err := r.db.ExecContext(
+		ctx,
 		query,
 		product.Name,
 		product.Desc,
 		product.Price,
 		product.Discount,
-		product.Stock,
+		product.CategoryID,
 		product.UpdatedAt,
 		product.ProductID,
+		product.Version,
 	)
 	if err != nil {
+		if database.IsForeignKeyViolation(err) {
+			return nil, errs.InvalidField("categoryId", "category %d does not exist", product.CategoryID)
+		}
 		return nil, err
 	}
 
-	p, err := r.GetProduct(product.ProductID)
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if rows == 0 {
+		return nil, r.notUpdated(ctx, product.ProductID)
+	}
+
+	p, err := r.GetProduct(ctx, product.ProductID)
 	if err != nil {
 		return nil, err
 	}
@@ -125,18 +263,34 @@ func (r *productRepo) UpdateProduct(product *products.Product) (*products.Produc
 	return p, nil
 }
 
-func (r *productRepo) DeleteProduct(productID string) error {
-	query := `DELETE FROM "products" WHERE "product_id" = $1;`
+func (r *productRepo) DeleteProduct(ctx context.Context, productID string, version int) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+
+	query := `DELETE FROM "products" WHERE "product_id" = $1 AND ($2 = 0 OR "version" = $2);`
 
-	result, err := r.db.ExecContext(context.Background(), query, productID)
+	result, err := r.db.ExecContext(ctx, query, productID, version)
 	if err != nil {
 		return err
 	}
 
-	_, err = result.RowsAffected()
+	rows, err := result.RowsAffected()
 	if err != nil {
 		return err
 	}
+	if rows == 0 {
+		return r.notUpdated(ctx, productID)
+	}
 
 	return nil
 }
+
+// notUpdated explains a conditional write that matched no row, either the
+// product is gone or someone else changed it first.
+func (r *productRepo) notUpdated(ctx context.Context, productID string) error {
+	current, err := r.GetProduct(ctx, productID)
+	if err != nil {
+		return err
+	}
+	return errs.PreconditionFailed("product %s was changed by someone else, its version is now %d", productID, current.Version)
+}
@@ -0,0 +1,228 @@
+package prodrepositories
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/codepnw/sales-api/database"
+	"github.com/codepnw/sales-api/modules/products"
+	"github.com/codepnw/sales-api/pkg/errs"
+)
+
+type productMemoryRepo struct {
+	db *database.MemoryDB
+}
+
+func NewProductMemoryRepository(db *database.MemoryDB) IProductRepo {
+	return &productMemoryRepo{db: db}
+}
+
+func (r *productMemoryRepo) CreateProduct(ctx context.Context, product *products.Product) (*products.Product, error) {
+	err := r.db.Update(func(tx *database.MemoryTx) error {
+		if _, ok := tx.Get("categories", strconv.Itoa(int(product.CategoryID))); !ok {
+			return errs.InvalidField("categoryId", "category %d does not exist", product.CategoryID)
+		}
+
+		product.ProductID = fmt.Sprintf("P%06d", tx.NextId("products"))
+		tx.Put("products", product.ProductID, *product)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
+func (r *productMemoryRepo) GetProducts(ctx context.Context, filter *products.ProductFilter) ([]*products.Product, int, error) {
+	prods := r.find(func(p *products.Product) bool {
+		switch {
+		case filter.CategoryID != nil && p.CategoryID != *filter.CategoryID:
+			return false
+		case filter.MinPrice != nil && p.Price < *filter.MinPrice:
+			return false
+		case filter.MaxPrice != nil && p.Price > *filter.MaxPrice:
+			return false
+		case filter.InStock && p.Stock == 0:
+			return false
+		}
+		return true
+	})
+
+	column, direction := sortOrder(filter)
+	less := func(a, b *products.Product) bool {
+		switch column {
+		case "name":
+			if a.Name != b.Name {
+				return a.Name < b.Name
+			}
+		case "price":
+			if a.Price != b.Price {
+				return a.Price < b.Price
+			}
+		default:
+			if !a.CreatedAt.Equal(b.CreatedAt) {
+				return a.CreatedAt.Before(b.CreatedAt)
+			}
+		}
+		return a.ProductID < b.ProductID
+	}
+	sort.Slice(prods, func(i, j int) bool {
+		if direction == "DESC" {
+			return less(prods[j], prods[i])
+		}
+		return less(prods[i], prods[j])
+	})
+
+	return page(prods, filter.Offset(), filter.Limit), len(prods), nil
+}
+
+// SearchProducts needs every term to prefix a word of the name or desc,
+// products matching in the name rank first.
+func (r *productMemoryRepo) SearchProducts(ctx context.Context, search *products.ProductSearch) ([]*products.Product, int, error) {
+	terms := search.Terms()
+	rank := make(map[string]int)
+
+	prods := r.find(func(p *products.Product) bool {
+		name := (&products.ProductSearch{Q: p.Name}).Terms()
+		desc := (&products.ProductSearch{Q: p.Desc}).Terms()
+
+		for _, t := range terms {
+			inName, inDesc := hasPrefix(name, t), hasPrefix(desc, t)
+			if !inName && !inDesc {
+				return false
+			}
+			if inName {
+				rank[p.ProductID] += 2
+			} else {
+				rank[p.ProductID]++
+			}
+		}
+		return len(terms) > 0
+	})
+
+	sort.Slice(prods, func(i, j int) bool {
+		a, b := prods[i], prods[j]
+		if rank[a.ProductID] != rank[b.ProductID] {
+			return rank[a.ProductID] > rank[b.ProductID]
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.ProductID < b.ProductID
+	})
+
+	return page(prods, search.Offset(), search.Limit), len(prods), nil
+}
+
+func (r *productMemoryRepo) GetProduct(ctx context.Context, productID string) (*products.Product, error) {
+	prod := products.Product{}
+
+	err := r.db.View(func(tx *database.MemoryTx) error {
+		row, ok := tx.Get("products", productID)
+		if !ok {
+			return errs.NotFound("product %s not found", productID)
+		}
+		prod = row.(products.Product)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &prod, nil
+}
+
+func (r *productMemoryRepo) UpdateProduct(ctx context.Context, product *products.Product) (*products.Product, error) {
+	err := r.db.Update(func(tx *database.MemoryTx) error {
+		row, ok := tx.Get("products", product.ProductID)
+		if !ok {
+			return errs.NotFound("product %s not found", product.ProductID)
+		}
+
+		current := row.(products.Product)
+		if err := checkVersion(&current, product.Version); err != nil {
+			return err
+		}
+
+		if _, ok := tx.Get("categories", strconv.Itoa(int(product.CategoryID))); !ok {
+			return errs.InvalidField("categoryId", "category %d does not exist", product.CategoryID)
+		}
+
+		product.Stock = current.Stock
+		product.CreatedAt = current.CreatedAt
+		product.Version = current.Version + 1
+		tx.Put("products", product.ProductID, *product)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
+func (r *productMemoryRepo) DeleteProduct(ctx context.Context, productID string, version int) error {
+	return r.db.Update(func(tx *database.MemoryTx) error {
+		row, ok := tx.Get("products", productID)
+		if !ok {
+			return errs.NotFound("product %s not found", productID)
+		}
+
+		current := row.(products.Product)
+		if err := checkVersion(&current, version); err != nil {
+			return err
+		}
+
+		tx.Delete("products", productID)
+		return nil
+	})
+}
+
+// checkVersion is the "version" = $n condition of the SQL repositories.
+func checkVersion(current *products.Product, version int) error {
+	if version != 0 && current.Version != version {
+		return errs.PreconditionFailed("product %s was changed by someone else, its version is now %d", current.ProductID, current.Version)
+	}
+	return nil
+}
+
+func (r *productMemoryRepo) find(match func(p *products.Product) bool) []*products.Product {
+	prods := make([]*products.Product, 0)
+
+	r.db.View(func(tx *database.MemoryTx) error {
+		for _, row := range tx.Rows("products") {
+			prod := row.(products.Product)
+			if match(&prod) {
+				prods = append(prods, &prod)
+			}
+		}
+		return nil
+	})
+
+	return prods
+}
+
+func hasPrefix(words []string, prefix string) bool {
+	for _, w := range words {
+		if strings.HasPrefix(w, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// page applies LIMIT and OFFSET to an already sorted slice.
+func page(prods []*products.Product, offset, limit int) []*products.Product {
+	if offset >= len(prods) {
+		return prods[:0]
+	}
+	end := offset + limit
+	if end > len(prods) {
+		end = len(prods)
+	}
+	return prods[offset:end]
+}
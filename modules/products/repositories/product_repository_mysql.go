@@ -0,0 +1,246 @@
+package prodrepositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/codepnw/sales-api/database"
+	"github.com/codepnw/sales-api/modules/products"
+	"github.com/codepnw/sales-api/pkg/errs"
+	"gorm.io/gorm"
+)
+
+const mysqlProductColumns = "product_id, name, " + database.MysqlDesc + ", price, discount, stock, category_id, version, created_at, updated_at"
+
+type productMysqlRepo struct {
+	db *gorm.DB
+}
+
+func NewProductMysqlRepository(db *gorm.DB) IProductRepo {
+	return &productMysqlRepo{db: db}
+}
+
+func (r *productMysqlRepo) CreateProduct(ctx context.Context, product *products.Product) (*products.Product, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		id, err := database.NextMysqlId(tx, "seq_product_id", "P")
+		if err != nil {
+			return err
+		}
+		product.ProductID = id
+
+		query := fmt.Sprintf(`
+			INSERT INTO products (%s)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+		`, mysqlProductColumns)
+		return tx.Exec(
+			query,
+			product.ProductID,
+			product.Name,
+			product.Desc,
+			product.Price,
+			product.Discount,
+			product.Stock,
+			product.CategoryID,
+			product.Version,
+			product.CreatedAt,
+			product.UpdatedAt,
+		).Error
+	})
+
+	if err != nil {
+		if database.IsForeignKeyViolation(err) {
+			return nil, errs.InvalidField("categoryId", "category %d does not exist", product.CategoryID)
+		}
+		return nil, err
+	}
+
+	return product, nil
+}
+
+func (r *productMysqlRepo) GetProducts(ctx context.Context, filter *products.ProductFilter) ([]*products.Product, int, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+
+	prods := make([]*products.Product, 0)
+	conditions := make([]string, 0)
+	args := make([]any, 0)
+
+	if filter.CategoryID != nil {
+		conditions = append(conditions, "category_id = ?")
+		args = append(args, *filter.CategoryID)
+	}
+	if filter.MinPrice != nil {
+		conditions = append(conditions, "price >= ?")
+		args = append(args, *filter.MinPrice)
+	}
+	if filter.MaxPrice != nil {
+		conditions = append(conditions, "price <= ?")
+		args = append(args, *filter.MaxPrice)
+	}
+	if filter.InStock {
+		conditions = append(conditions, "stock > 0")
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	query := fmt.Sprintf("SELECT COUNT(*) FROM products %s;", where)
+	if err := r.db.WithContext(ctx).Raw(query, args...).Scan(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	orderBy, direction := sortOrder(filter)
+
+	args = append(args, filter.Limit, filter.Offset())
+	query = fmt.Sprintf(`
+		SELECT %s
+		FROM products
+		%s
+		ORDER BY %s %s, product_id %s
+		LIMIT ? OFFSET ?;
+	`, mysqlProductColumns, where, orderBy, direction, direction)
+
+	if err := r.db.WithContext(ctx).Raw(query, args...).Scan(&prods).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return prods, total, nil
+}
+
+// SearchProducts uses the FULLTEXT index in boolean mode, "cof mil" becomes
+// "+cof* +mil*" to match the prefix search of the PostgreSQL repository.
+func (r *productMysqlRepo) SearchProducts(ctx context.Context, search *products.ProductSearch) ([]*products.Product, int, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+
+	prods := make([]*products.Product, 0)
+
+	terms := search.Terms()
+	for i, t := range terms {
+		terms[i] = "+" + t + "*"
+	}
+	against := strings.Join(terms, " ")
+
+	match := fmt.Sprintf("MATCH (name, %s) AGAINST (? IN BOOLEAN MODE)", database.MysqlDesc)
+
+	var total int
+	query := fmt.Sprintf("SELECT COUNT(*) FROM products WHERE %s;", match)
+	if err := r.db.WithContext(ctx).Raw(query, against).Scan(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	query = fmt.Sprintf(`
+		SELECT %s
+		FROM products
+		WHERE %s
+		ORDER BY %s DESC, name, product_id
+		LIMIT ? OFFSET ?;
+	`, mysqlProductColumns, match, match)
+	err := r.db.WithContext(ctx).Raw(query, against, against, search.Limit, search.Offset()).Scan(&prods).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return prods, total, nil
+}
+
+func (r *productMysqlRepo) GetProduct(ctx context.Context, productID string) (*products.Product, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+
+	prod := products.Product{}
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM products
+		WHERE product_id = ?
+		LIMIT 1;
+	`, mysqlProductColumns)
+	result := r.db.WithContext(ctx).Raw(query, productID).Scan(&prod)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, errs.NotFound("product %s not found", productID)
+	}
+
+	return &prod, nil
+}
+
+func (r *productMysqlRepo) UpdateProduct(ctx context.Context, product *products.Product) (*products.Product, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+
+	query := fmt.Sprintf(`
+		UPDATE products
+		SET
+			name = ?,
+			%s = ?,
+			price = ?,
+			discount = ?,
+			category_id = ?,
+			updated_at = ?,
+			version = version + 1
+		WHERE product_id = ?
+		AND (? = 0 OR version = ?);
+	`, database.MysqlDesc)
+	result := r.db.WithContext(ctx).Exec(
+		query,
+		product.Name,
+		product.Desc,
+		product.Price,
+		product.Discount,
+		product.CategoryID,
+		product.UpdatedAt,
+		product.ProductID,
+		product.Version,
+		product.Version,
+	)
+	if err := result.Error; err != nil {
+		if database.IsForeignKeyViolation(err) {
+			return nil, errs.InvalidField("categoryId", "category %d does not exist", product.CategoryID)
+		}
+		return nil, err
+	}
+	if result.RowsAffected == 0 {
+		return nil, r.notUpdated(ctx, product.ProductID)
+	}
+
+	p, err := r.GetProduct(ctx, product.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func (r *productMysqlRepo) DeleteProduct(ctx context.Context, productID string, version int) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+
+	query := "DELETE FROM products WHERE product_id = ? AND (? = 0 OR version = ?);"
+	result := r.db.WithContext(ctx).Exec(query, productID, version, version)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return r.notUpdated(ctx, productID)
+	}
+
+	return nil
+}
+
+func (r *productMysqlRepo) notUpdated(ctx context.Context, productID string) error {
+	current, err := r.GetProduct(ctx, productID)
+	if err != nil {
+		return err
+	}
+	return errs.PreconditionFailed("product %s was changed by someone else, its version is now %d", productID, current.Version)
+}
@@ -0,0 +1,56 @@
+package prodrepositories
+
+import (
+	"context"
+	"strings"
+
+	"github.com/codepnw/sales-api/database"
+	"github.com/codepnw/sales-api/modules/products"
+	"github.com/jmoiron/sqlx"
+)
+
+// productSqliteRepo shares the PostgreSQL queries, only search differs
+// since SQLite has no tsvector and uses the products_fts table instead.
+type productSqliteRepo struct {
+	*productRepo
+}
+
+func NewProductSqliteRepository(db *sqlx.DB) IProductRepo {
+	return &productSqliteRepo{productRepo: &productRepo{db: db}}
+}
+
+// SearchProducts matches prefixes through FTS5, "cof mil" becomes
+// `"cof"* "mil"*` which FTS5 treats as both terms required.
+func (r *productSqliteRepo) SearchProducts(ctx context.Context, search *products.ProductSearch) ([]*products.Product, int, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+
+	prods := make([]*products.Product, 0)
+
+	terms := search.Terms()
+	for i, t := range terms {
+		terms[i] = `"` + t + `"*`
+	}
+	match := strings.Join(terms, " ")
+
+	var total int
+	query := `SELECT COUNT(*) FROM "products_fts" WHERE "products_fts" MATCH $1;`
+	if err := r.db.GetContext(ctx, &total, query, match); err != nil {
+		return nil, 0, err
+	}
+
+	query = `
+		SELECT p."product_id", p."name", p."desc", p."price", p."discount", p."stock", p."category_id", p."version", p."created_at", p."updated_at"
+		FROM "products_fts" f
+		JOIN "products" p ON p."seq" = f."rowid"
+		WHERE "products_fts" MATCH $1
+		ORDER BY bm25("products_fts"), p."name", p."product_id"
+		LIMIT $2 OFFSET $3;
+	`
+	err := r.db.SelectContext(ctx, &prods, query, match, search.Limit, search.Offset())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return prods, total, nil
+}
@@ -1,21 +1,23 @@
 package prodservices
 
 import (
-	"database/sql"
+	"context"
 	"fmt"
 
 	"github.com/codepnw/sales-api/modules/products"
 	prodrepositories "github.com/codepnw/sales-api/modules/products/repositories"
+	"github.com/codepnw/sales-api/pkg/errs"
 	"github.com/codepnw/sales-api/pkg/logs"
 	"github.com/codepnw/sales-api/pkg/utils"
 )
 
 type IProductService interface {
-	CreateProduct(prod *products.ProductRequest) (*products.Product, error)
-	GetProducts() ([]*products.Product, error)
-	GetProduct(productId string) (*products.Product, error)
-	UpdateProduct(productId string, req *products.ProductRequest) (*products.Product, error)
-	DeleteProduct(productId string) error
+	CreateProduct(ctx context.Context, prod *products.ProductRequest) (*products.Product, error)
+	GetProducts(ctx context.Context, filter *products.ProductFilter) ([]*products.Product, *utils.Pagination, error)
+	SearchProducts(ctx context.Context, search *products.ProductSearch) ([]*products.Product, *utils.Pagination, error)
+	GetProduct(ctx context.Context, productId string) (*products.Product, error)
+	UpdateProduct(ctx context.Context, productId string, version int, req *products.ProductRequest) (*products.Product, error)
+	DeleteProduct(ctx context.Context, productId string, version int) error
 }
 
 type productService struct {
@@ -26,86 +28,116 @@ func NewProductService(repository prodrepositories.IProductRepo) IProductService
 	return &productService{repository: repository}
 }
 
-func (s *productService) CreateProduct(req *products.ProductRequest) (*products.Product, error) {
-	var stock uint
-	if req.Stock == 0 {
-		stock = 1
-	}
-
-	if req.Price <= 0 {
-		logs.Error("price is zero")
-		return nil, fmt.Errorf("price is zero")
-	}
-
+func (s *productService) CreateProduct(ctx context.Context, req *products.ProductRequest) (*products.Product, error) {
 	product := products.Product{
 		Name:       req.Name,
 		Desc:       req.Desc,
 		Price:      req.Price,
 		Discount:   req.Discount,
-		Stock:      stock,
 		CategoryID: req.CategoryID,
+		Version:    1,
 		CreatedAt:  utils.LocalTime(),
 		UpdatedAt:  utils.LocalTime(),
 	}
+	if req.Stock != nil {
+		product.Stock = *req.Stock
+	}
 
-	p, err := s.repository.CreateProduct(&product)
+	p, err := s.repository.CreateProduct(ctx, &product)
 	if err != nil {
-		logs.Error(err)
+		logs.ErrorContext(ctx, err)
+		if errs.Known(err) {
+			return nil, err
+		}
 		return nil, fmt.Errorf("failed create product")
 	}
 
 	return p, nil
 }
 
-func (s *productService) GetProducts() ([]*products.Product, error) {
-	p, err := s.repository.GetProducts()
+func (s *productService) GetProducts(ctx context.Context, filter *products.ProductFilter) ([]*products.Product, *utils.Pagination, error) {
+	filter.Normalize()
+
+	if filter.MinPrice != nil && filter.MaxPrice != nil && *filter.MinPrice > *filter.MaxPrice {
+		return nil, nil, errs.Validation("minPrice is greater than maxPrice")
+	}
+
+	p, total, err := s.repository.GetProducts(ctx, filter)
 	if err != nil {
-		logs.Error(err)
-		return nil, fmt.Errorf("failed get products")
+		logs.ErrorContext(ctx, err)
+		return nil, nil, fmt.Errorf("failed get products")
 	}
 
-	return p, nil
+	return p, utils.NewPagination(filter.PageQuery, total), nil
+}
+
+func (s *productService) SearchProducts(ctx context.Context, search *products.ProductSearch) ([]*products.Product, *utils.Pagination, error) {
+	search.Normalize()
+
+	if search.TsQuery() == "" {
+		return nil, nil, errs.Validation("q is required")
+	}
+
+	p, total, err := s.repository.SearchProducts(ctx, search)
+	if err != nil {
+		logs.ErrorContext(ctx, err)
+		return nil, nil, fmt.Errorf("failed search products")
+	}
+
+	return p, utils.NewPagination(search.PageQuery, total), nil
 }
 
-func (s *productService) GetProduct(productId string) (*products.Product, error) {
-	p, err := s.repository.GetProduct(productId)
+func (s *productService) GetProduct(ctx context.Context, productId string) (*products.Product, error) {
+	p, err := s.repository.GetProduct(ctx, productId)
 	if err != nil {
-		logs.Error(err)
+		logs.ErrorContext(ctx, err)
+		if errs.Known(err) {
+			return nil, err
+		}
 		return nil, fmt.Errorf("failed get product")
 	}
 
 	return p, nil
 }
 
-func (s *productService) UpdateProduct(productId string, req *products.ProductRequest) (*products.Product, error) {
+// UpdateProduct writes every field but the stock, which only moves through
+// POST /products/:productId/stock and its inventory log.
+func (s *productService) UpdateProduct(ctx context.Context, productId string, version int, req *products.ProductRequest) (*products.Product, error) {
+	if req.Stock != nil {
+		return nil, errs.InvalidField("stock", "can only be changed with a stock adjustment")
+	}
+
 	product := products.Product{
 		ProductID:  productId,
 		Name:       req.Name,
 		Desc:       req.Desc,
 		Price:      req.Price,
 		Discount:   req.Discount,
-		Stock:      req.Stock,
 		CategoryID: req.CategoryID,
+		Version:    version,
 		UpdatedAt:  utils.LocalTime(),
 	}
 
-	p, err := s.repository.UpdateProduct(&product)
+	p, err := s.repository.UpdateProduct(ctx, &product)
 	if err != nil {
-		logs.Error(err)
-		return nil, err
+		logs.ErrorContext(ctx, err)
+		if errs.Known(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed update product")
 	}
 
 	return p, nil
 }
 
-func (s *productService) DeleteProduct(productId string) error {
-	err := s.repository.DeleteProduct(productId)
+func (s *productService) DeleteProduct(ctx context.Context, productId string, version int) error {
+	err := s.repository.DeleteProduct(ctx, productId, version)
 	if err != nil {
-		logs.Error(err)
-		if err == sql.ErrNoRows {
-			return fmt.Errorf("product id not found")
+		logs.ErrorContext(ctx, err)
+		if errs.Known(err) {
+			return err
 		}
-		return err
+		return fmt.Errorf("failed delete product")
 	}
 	return nil
 }
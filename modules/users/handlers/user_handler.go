@@ -0,0 +1,96 @@
+package userhandlers
+
+import (
+	"net/http"
+
+	"github.com/codepnw/sales-api/modules/middlewares"
+	"github.com/codepnw/sales-api/modules/users"
+	userservices "github.com/codepnw/sales-api/modules/users/services"
+	"github.com/codepnw/sales-api/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type userHandler struct {
+	service userservices.IUserService
+}
+
+func NewUserHandler(service userservices.IUserService) *userHandler {
+	return &userHandler{service: service}
+}
+
+type userErr string
+
+const (
+	signUpError  userErr = "users-001"
+	signInError  userErr = "users-002"
+	refreshError userErr = "users-003"
+	signOutError userErr = "users-004"
+)
+
+func (h *userHandler) SignUp(c *gin.Context) {
+	request := users.UserSignUpRequest{}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.NewResponse(c).BindError(string(signUpError), err)
+		return
+	}
+
+	user, err := h.service.SignUp(c.Request.Context(), &request)
+	if err != nil {
+		utils.NewResponse(c).Fail(string(signUpError), err)
+		return
+	}
+
+	utils.NewResponse(c).Success(http.StatusCreated, user)
+}
+
+func (h *userHandler) SignIn(c *gin.Context) {
+	request := users.UserSignInRequest{}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.NewResponse(c).BindError(string(signInError), err)
+		return
+	}
+
+	passport, err := h.service.SignIn(c.Request.Context(), &request)
+	if err != nil {
+		utils.NewResponse(c).Fail(string(signInError), err)
+		return
+	}
+
+	utils.NewResponse(c).Success(http.StatusOK, passport)
+}
+
+func (h *userHandler) RefreshToken(c *gin.Context) {
+	request := users.UserRefreshRequest{}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.NewResponse(c).BindError(string(refreshError), err)
+		return
+	}
+
+	token, err := h.service.RefreshToken(c.Request.Context(), &request)
+	if err != nil {
+		utils.NewResponse(c).Fail(string(refreshError), err)
+		return
+	}
+
+	utils.NewResponse(c).Success(http.StatusOK, token)
+}
+
+func (h *userHandler) SignOut(c *gin.Context) {
+	request := users.UserSignOutRequest{}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.NewResponse(c).BindError(string(signOutError), err)
+		return
+	}
+
+	userId := c.GetString(middlewares.ContextUserId)
+	if err := h.service.SignOut(c.Request.Context(), userId, request.OauthId); err != nil {
+		utils.NewResponse(c).Fail(string(signOutError), err)
+		return
+	}
+
+	utils.NewResponse(c).Success(http.StatusNoContent, nil)
+}
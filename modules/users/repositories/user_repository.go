@@ -0,0 +1,216 @@
+package userrepositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/codepnw/sales-api/database"
+	"github.com/codepnw/sales-api/modules/users"
+	"github.com/codepnw/sales-api/pkg/errs"
+	"github.com/jmoiron/sqlx"
+)
+
+var Schemas = []database.TableSchema{
+	{
+		Table: "users",
+		Columns: map[string]string{
+			"user_id":    database.TypeVarchar,
+			"email":      database.TypeVarchar,
+			"username":   database.TypeVarchar,
+			"password":   database.TypeVarchar,
+			"role_id":    database.TypeInt,
+			"created_at": database.TypeTimestamp,
+			"updated_at": database.TypeTimestamp,
+		},
+	},
+	{
+		Table: "oauth",
+		Columns: map[string]string{
+			"oauth_id":      database.TypeUUID,
+			"user_id":       database.TypeVarchar,
+			"access_token":  database.TypeVarchar,
+			"refresh_token": database.TypeVarchar,
+			"created_at":    database.TypeTimestamp,
+			"updated_at":    database.TypeTimestamp,
+		},
+	},
+}
+
+type IUserRepo interface {
+	CreateUser(user *users.User) (*users.User, error)
+	GetUserByEmail(email string) (*users.User, error)
+	GetUserById(userId string) (*users.User, error)
+	CreateOauth(oauth *users.Oauth) (*users.Oauth, error)
+	GetOauthByRefreshToken(refreshToken string) (*users.Oauth, error)
+	UpdateOauth(oauth *users.Oauth) error
+	// DeleteOauth only removes a session that belongs to userId.
+	DeleteOauth(userId, oauthId string) error
+}
+
+type userRepo struct {
+	db *sqlx.DB
+}
+
+func NewUserRepository(db *sqlx.DB) IUserRepo {
+	return &userRepo{db: db}
+}
+
+func (r *userRepo) CreateUser(user *users.User) (*users.User, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	query := `
+		INSERT INTO "users" ("email", "username", "password", "role_id", "created_at", "updated_at")
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING "user_id";
+	`
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		user.Email,
+		user.Username,
+		user.Password,
+		user.RoleId,
+		user.CreatedAt,
+		user.UpdatedAt,
+	).Scan(&user.UserId)
+
+	if err != nil {
+		if database.IsUniqueViolation(err) {
+			return nil, errs.Conflict("email or username already exists")
+		}
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (r *userRepo) GetUserByEmail(email string) (*users.User, error) {
+	user := users.User{}
+
+	query := `
+		SELECT "user_id", "email", "username", "password", "role_id", "created_at", "updated_at"
+		FROM "users"
+		WHERE "email" = $1
+		LIMIT 1;
+	`
+	err := r.db.Get(&user, query, email)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.NotFound("user not found")
+		}
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (r *userRepo) GetUserById(userId string) (*users.User, error) {
+	user := users.User{}
+
+	query := `
+		SELECT "user_id", "email", "username", "password", "role_id", "created_at", "updated_at"
+		FROM "users"
+		WHERE "user_id" = $1
+		LIMIT 1;
+	`
+	err := r.db.Get(&user, query, userId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.NotFound("user not found")
+		}
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (r *userRepo) CreateOauth(oauth *users.Oauth) (*users.Oauth, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	query := `
+		INSERT INTO "oauth" ("user_id", "access_token", "refresh_token", "created_at", "updated_at")
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING "oauth_id";
+	`
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		oauth.UserId,
+		oauth.AccessToken,
+		oauth.RefreshToken,
+		oauth.CreatedAt,
+		oauth.UpdatedAt,
+	).Scan(&oauth.OauthId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return oauth, nil
+}
+
+func (r *userRepo) GetOauthByRefreshToken(refreshToken string) (*users.Oauth, error) {
+	oauth := users.Oauth{}
+
+	query := `
+		SELECT "oauth_id", "user_id", "access_token", "refresh_token", "created_at", "updated_at"
+		FROM "oauth"
+		WHERE "refresh_token" = $1
+		LIMIT 1;
+	`
+	err := r.db.Get(&oauth, query, refreshToken)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errs.NotFound("oauth not found")
+		}
+		return nil, err
+	}
+
+	return &oauth, nil
+}
+
+func (r *userRepo) UpdateOauth(oauth *users.Oauth) error {
+	query := `
+		UPDATE "oauth"
+		SET
+			"access_token" = $1,
+			"refresh_token" = $2,
+			"updated_at" = $3
+		WHERE "oauth_id" = $4;
+	`
+	_, err := r.db.ExecContext(
+		context.Background(),
+		query,
+		oauth.AccessToken,
+		oauth.RefreshToken,
+		oauth.UpdatedAt,
+		oauth.OauthId,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *userRepo) DeleteOauth(userId, oauthId string) error {
+	query := `DELETE FROM "oauth" WHERE "oauth_id" = $1 AND "user_id" = $2;`
+
+	result, err := r.db.ExecContext(context.Background(), query, oauthId, userId)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return errs.NotFound("oauth %s not found", oauthId)
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package userrepositories
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/codepnw/sales-api/database"
+	"github.com/codepnw/sales-api/modules/users"
+	"github.com/codepnw/sales-api/pkg/errs"
+	"github.com/google/uuid"
+)
+
+type userMemoryRepo struct {
+	db *database.MemoryDB
+}
+
+func NewUserMemoryRepository(db *database.MemoryDB) IUserRepo {
+	return &userMemoryRepo{db: db}
+}
+
+func (r *userMemoryRepo) CreateUser(user *users.User) (*users.User, error) {
+	err := r.db.Update(func(tx *database.MemoryTx) error {
+		if _, ok := tx.Get("user_roles", strconv.Itoa(user.RoleId)); !ok {
+			return errs.Validation("role %d not found", user.RoleId)
+		}
+
+		for _, row := range tx.Rows("users") {
+			u := row.(users.User)
+			if u.Email == user.Email || u.Username == user.Username {
+				return errs.Conflict("email or username already exists")
+			}
+		}
+
+		user.UserId = fmt.Sprintf("U%06d", tx.NextId("users"))
+		tx.Put("users", user.UserId, *user)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (r *userMemoryRepo) GetUserByEmail(email string) (*users.User, error) {
+	return r.findUser(func(u *users.User) bool { return u.Email == email })
+}
+
+func (r *userMemoryRepo) GetUserById(userId string) (*users.User, error) {
+	return r.findUser(func(u *users.User) bool { return u.UserId == userId })
+}
+
+func (r *userMemoryRepo) CreateOauth(oauth *users.Oauth) (*users.Oauth, error) {
+	err := r.db.Update(func(tx *database.MemoryTx) error {
+		if _, ok := tx.Get("users", oauth.UserId); !ok {
+			return fmt.Errorf("user %s not found", oauth.UserId)
+		}
+
+		oauth.OauthId = uuid.NewString()
+		tx.Put("oauth", oauth.OauthId, *oauth)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return oauth, nil
+}
+
+func (r *userMemoryRepo) GetOauthByRefreshToken(refreshToken string) (*users.Oauth, error) {
+	oauth := users.Oauth{}
+
+	err := r.db.View(func(tx *database.MemoryTx) error {
+		for _, row := range tx.Rows("oauth") {
+			if o := row.(users.Oauth); o.RefreshToken == refreshToken {
+				oauth = o
+				return nil
+			}
+		}
+		return errs.NotFound("oauth not found")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &oauth, nil
+}
+
+func (r *userMemoryRepo) UpdateOauth(oauth *users.Oauth) error {
+	return r.db.Update(func(tx *database.MemoryTx) error {
+		row, ok := tx.Get("oauth", oauth.OauthId)
+		if !ok {
+			return nil
+		}
+
+		current := row.(users.Oauth)
+		current.AccessToken = oauth.AccessToken
+		current.RefreshToken = oauth.RefreshToken
+		current.UpdatedAt = oauth.UpdatedAt
+		tx.Put("oauth", oauth.OauthId, current)
+		return nil
+	})
+}
+
+func (r *userMemoryRepo) DeleteOauth(userId, oauthId string) error {
+	return r.db.Update(func(tx *database.MemoryTx) error {
+		row, ok := tx.Get("oauth", oauthId)
+		if !ok || row.(users.Oauth).UserId != userId {
+			return errs.NotFound("oauth %s not found", oauthId)
+		}
+
+		tx.Delete("oauth", oauthId)
+		return nil
+	})
+}
+
+func (r *userMemoryRepo) findUser(match func(u *users.User) bool) (*users.User, error) {
+	user := users.User{}
+
+	err := r.db.View(func(tx *database.MemoryTx) error {
+		for _, row := range tx.Rows("users") {
+			if u := row.(users.User); match(&u) {
+				user = u
+				return nil
+			}
+		}
+		return errs.NotFound("user not found")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
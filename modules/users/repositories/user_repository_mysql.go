@@ -0,0 +1,168 @@
+package userrepositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/codepnw/sales-api/database"
+	"github.com/codepnw/sales-api/modules/users"
+	"github.com/codepnw/sales-api/pkg/errs"
+	"gorm.io/gorm"
+)
+
+type userMysqlRepo struct {
+	db *gorm.DB
+}
+
+func NewUserMysqlRepository(db *gorm.DB) IUserRepo {
+	return &userMysqlRepo{db: db}
+}
+
+func (r *userMysqlRepo) CreateUser(user *users.User) (*users.User, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		id, err := database.NextMysqlId(tx, "seq_user_id", "U")
+		if err != nil {
+			return err
+		}
+		user.UserId = id
+
+		query := `
+			INSERT INTO users (user_id, email, username, password, role_id, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?);
+		`
+		return tx.Exec(
+			query,
+			user.UserId,
+			user.Email,
+			user.Username,
+			user.Password,
+			user.RoleId,
+			user.CreatedAt,
+			user.UpdatedAt,
+		).Error
+	})
+
+	if err != nil {
+		if database.IsUniqueViolation(err) {
+			return nil, errs.Conflict("email or username already exists")
+		}
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (r *userMysqlRepo) GetUserByEmail(email string) (*users.User, error) {
+	return r.getUserBy("email", email)
+}
+
+func (r *userMysqlRepo) GetUserById(userId string) (*users.User, error) {
+	return r.getUserBy("user_id", userId)
+}
+
+// getUserBy is only called with a fixed column name, never with user input.
+func (r *userMysqlRepo) getUserBy(column, value string) (*users.User, error) {
+	user := users.User{}
+
+	query := fmt.Sprintf(`
+		SELECT user_id, email, username, password, role_id, created_at, updated_at
+		FROM users
+		WHERE %s = ?
+		LIMIT 1;
+	`, column)
+	result := r.db.Raw(query, value).Scan(&user)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, errs.NotFound("user not found")
+	}
+
+	return &user, nil
+}
+
+func (r *userMysqlRepo) CreateOauth(oauth *users.Oauth) (*users.Oauth, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	db := r.db.WithContext(ctx)
+
+	// the CHAR(36) default can't be read back without RETURNING
+	if err := db.Raw("SELECT UUID();").Scan(&oauth.OauthId).Error; err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO oauth (oauth_id, user_id, access_token, refresh_token, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?);
+	`
+	err := db.Exec(
+		query,
+		oauth.OauthId,
+		oauth.UserId,
+		oauth.AccessToken,
+		oauth.RefreshToken,
+		oauth.CreatedAt,
+		oauth.UpdatedAt,
+	).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return oauth, nil
+}
+
+func (r *userMysqlRepo) GetOauthByRefreshToken(refreshToken string) (*users.Oauth, error) {
+	oauth := users.Oauth{}
+
+	query := `
+		SELECT oauth_id, user_id, access_token, refresh_token, created_at, updated_at
+		FROM oauth
+		WHERE refresh_token = ?
+		LIMIT 1;
+	`
+	result := r.db.Raw(query, refreshToken).Scan(&oauth)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, errs.NotFound("oauth not found")
+	}
+
+	return &oauth, nil
+}
+
+func (r *userMysqlRepo) UpdateOauth(oauth *users.Oauth) error {
+	query := `
+		UPDATE oauth
+		SET
+			access_token = ?,
+			refresh_token = ?,
+			updated_at = ?
+		WHERE oauth_id = ?;
+	`
+	return r.db.Exec(
+		query,
+		oauth.AccessToken,
+		oauth.RefreshToken,
+		oauth.UpdatedAt,
+		oauth.OauthId,
+	).Error
+}
+
+func (r *userMysqlRepo) DeleteOauth(userId, oauthId string) error {
+	result := r.db.Exec("DELETE FROM oauth WHERE oauth_id = ? AND user_id = ?;", oauthId, userId)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.NotFound("oauth %s not found", oauthId)
+	}
+
+	return nil
+}
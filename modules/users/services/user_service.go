@@ -0,0 +1,172 @@
+package userservices
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/codepnw/sales-api/config"
+	"github.com/codepnw/sales-api/modules/users"
+	userrepositories "github.com/codepnw/sales-api/modules/users/repositories"
+	"github.com/codepnw/sales-api/pkg/auth"
+	"github.com/codepnw/sales-api/pkg/errs"
+	"github.com/codepnw/sales-api/pkg/logs"
+	"github.com/codepnw/sales-api/pkg/utils"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type IUserService interface {
+	SignUp(ctx context.Context, req *users.UserSignUpRequest) (*users.User, error)
+	SignIn(ctx context.Context, req *users.UserSignInRequest) (*users.UserPassport, error)
+	RefreshToken(ctx context.Context, req *users.UserRefreshRequest) (*users.UserToken, error)
+	SignOut(ctx context.Context, userId, oauthId string) error
+}
+
+type userService struct {
+	repo userrepositories.IUserRepo
+	cfg  config.ConfigJwt
+}
+
+func NewUserService(repo userrepositories.IUserRepo, cfg config.ConfigJwt) IUserService {
+	return &userService{repo: repo, cfg: cfg}
+}
+
+func (s *userService) SignUp(ctx context.Context, req *users.UserSignUpRequest) (*users.User, error) {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		logs.ErrorContext(ctx, err)
+		return nil, fmt.Errorf("failed hash password")
+	}
+
+	user := users.User{
+		Email:     strings.ToLower(strings.TrimSpace(req.Email)),
+		Username:  strings.TrimSpace(req.Username),
+		Password:  string(hashed),
+		RoleId:    users.DefaultRoleId,
+		CreatedAt: utils.LocalTime(),
+		UpdatedAt: utils.LocalTime(),
+	}
+
+	result, err := s.repo.CreateUser(&user)
+	if err != nil {
+		logs.ErrorContext(ctx, err)
+		if errs.Known(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed sign up user")
+	}
+
+	return result, nil
+}
+
+func (s *userService) SignIn(ctx context.Context, req *users.UserSignInRequest) (*users.UserPassport, error) {
+	user, err := s.repo.GetUserByEmail(strings.ToLower(strings.TrimSpace(req.Email)))
+	if err != nil {
+		logs.ErrorContext(ctx, err)
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.Unauthorized("email or password is invalid")
+		}
+		return nil, fmt.Errorf("failed sign in user")
+	}
+
+	// bcrypt accepts the $2y$ prefix used by the seeded users.
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
+		return nil, errs.Unauthorized("email or password is invalid")
+	}
+
+	token, err := s.newToken(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	oauth, err := s.repo.CreateOauth(&users.Oauth{
+		UserId:       user.UserId,
+		AccessToken:  token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		CreatedAt:    utils.LocalTime(),
+		UpdatedAt:    utils.LocalTime(),
+	})
+	if err != nil {
+		logs.ErrorContext(ctx, err)
+		return nil, fmt.Errorf("failed sign in user")
+	}
+	token.OauthId = oauth.OauthId
+
+	return &users.UserPassport{User: user, Token: token}, nil
+}
+
+func (s *userService) RefreshToken(ctx context.Context, req *users.UserRefreshRequest) (*users.UserToken, error) {
+	if _, err := auth.ParseToken(s.cfg, auth.Refresh, req.RefreshToken); err != nil {
+		logs.ErrorContext(ctx, err)
+		return nil, errs.Unauthorized("refresh token is invalid")
+	}
+
+	// a signed out session or a deleted user can't refresh, anything else
+	// is the database failing and not the caller's fault
+	oauth, err := s.repo.GetOauthByRefreshToken(req.RefreshToken)
+	if err != nil {
+		logs.ErrorContext(ctx, err)
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.Unauthorized("refresh token is invalid")
+		}
+		return nil, fmt.Errorf("failed refresh token")
+	}
+
+	user, err := s.repo.GetUserById(oauth.UserId)
+	if err != nil {
+		logs.ErrorContext(ctx, err)
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.Unauthorized("refresh token is invalid")
+		}
+		return nil, fmt.Errorf("failed refresh token")
+	}
+
+	token, err := s.newToken(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	token.OauthId = oauth.OauthId
+
+	oauth.AccessToken = token.AccessToken
+	oauth.RefreshToken = token.RefreshToken
+	oauth.UpdatedAt = utils.LocalTime()
+
+	if err := s.repo.UpdateOauth(oauth); err != nil {
+		logs.ErrorContext(ctx, err)
+		return nil, fmt.Errorf("failed refresh token")
+	}
+
+	return token, nil
+}
+
+// SignOut ends one of the caller's own sessions, an oauthId of another user
+// is reported as not found.
+func (s *userService) SignOut(ctx context.Context, userId, oauthId string) error {
+	if err := s.repo.DeleteOauth(userId, oauthId); err != nil {
+		logs.ErrorContext(ctx, err)
+		if errs.Known(err) {
+			return err
+		}
+		return fmt.Errorf("failed sign out user")
+	}
+	return nil
+}
+
+func (s *userService) newToken(ctx context.Context, user *users.User) (*users.UserToken, error) {
+	claims := &auth.Claims{UserId: user.UserId, RoleId: user.RoleId}
+
+	accessToken, err := auth.NewToken(s.cfg, auth.Access, claims)
+	if err != nil {
+		logs.ErrorContext(ctx, err)
+		return nil, fmt.Errorf("failed generate access token")
+	}
+
+	refreshToken, err := auth.NewToken(s.cfg, auth.Refresh, claims)
+	if err != nil {
+		logs.ErrorContext(ctx, err)
+		return nil, fmt.Errorf("failed generate refresh token")
+	}
+
+	return &users.UserToken{AccessToken: accessToken, RefreshToken: refreshToken}, nil
+}
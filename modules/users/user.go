@@ -0,0 +1,54 @@
+package users
+
+import "time"
+
+const DefaultRoleId int = 1
+
+type User struct {
+	UserId    string    `db:"user_id" json:"userId"`
+	Email     string    `db:"email" json:"email"`
+	Username  string    `db:"username" json:"username"`
+	Password  string    `db:"password" json:"-"`
+	RoleId    int       `db:"role_id" json:"roleId"`
+	CreatedAt time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt time.Time `db:"updated_at" json:"updatedAt"`
+}
+
+type UserSignUpRequest struct {
+	Email    string `json:"email" form:"email" binding:"required,email,max=255"`
+	Username string `json:"username" form:"username" binding:"required,max=255"`
+	Password string `json:"password" form:"password" binding:"required,max=72"`
+}
+
+type UserSignInRequest struct {
+	Email    string `json:"email" form:"email" binding:"required,email"`
+	Password string `json:"password" form:"password" binding:"required"`
+}
+
+type UserRefreshRequest struct {
+	RefreshToken string `json:"refreshToken" form:"refresh_token" binding:"required"`
+}
+
+type UserSignOutRequest struct {
+	OauthId string `json:"oauthId" form:"oauth_id" binding:"required,uuid"`
+}
+
+type Oauth struct {
+	OauthId      string    `db:"oauth_id" json:"oauthId"`
+	UserId       string    `db:"user_id" json:"userId"`
+	AccessToken  string    `db:"access_token" json:"accessToken"`
+	RefreshToken string    `db:"refresh_token" json:"refreshToken"`
+	CreatedAt    time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt    time.Time `db:"updated_at" json:"updatedAt"`
+}
+
+type UserToken struct {
+	OauthId      string `json:"oauthId"`
+	AccessToken  string `json:"accessToken"`
+	RefreshToken string `json:"refreshToken"`
+}
+
+type UserPassport struct {
+	User  *User      `json:"user"`
+	Token *UserToken `json:"token"`
+}
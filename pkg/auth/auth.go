@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/codepnw/sales-api/config"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type TokenType string
+
+const (
+	Access  TokenType = "access"
+	Refresh TokenType = "refresh"
+)
+
+type Claims struct {
+	UserId string `json:"userId"`
+	RoleId int    `json:"roleId"`
+}
+
+type authClaims struct {
+	*Claims
+	jwt.RegisteredClaims
+}
+
+func key(cfg config.ConfigJwt, tokenType TokenType) ([]byte, int, error) {
+	switch tokenType {
+	case Access:
+		return cfg.AccessKey(), cfg.AccessExpiresAt(), nil
+	case Refresh:
+		return cfg.RefreshKey(), cfg.RefreshExpiresAt(), nil
+	}
+	return nil, 0, fmt.Errorf("unknown token type: %s", tokenType)
+}
+
+func NewToken(cfg config.ConfigJwt, tokenType TokenType, claims *Claims) (string, error) {
+	secret, expires, err := key(cfg, tokenType)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &authClaims{
+		Claims: claims,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    "sales-api",
+			Subject:   string(tokenType),
+			Audience:  []string{"sales-api"},
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Duration(expires) * time.Second)),
+			NotBefore: jwt.NewNumericDate(now),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+	})
+
+	return token.SignedString(secret)
+}
+
+func ParseToken(cfg config.ConfigJwt, tokenType TokenType, tokenString string) (*Claims, error) {
+	secret, _, err := key(cfg, tokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := jwt.ParseWithClaims(tokenString, &authClaims{}, func(t *jwt.Token) (any, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("signing method is invalid")
+		}
+		return secret, nil
+	})
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenMalformed) {
+			return nil, fmt.Errorf("token format is invalid")
+		}
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, fmt.Errorf("token had expired")
+		}
+		return nil, fmt.Errorf("parse token failed")
+	}
+
+	claims, ok := token.Claims.(*authClaims)
+	if !ok || claims.Claims == nil {
+		return nil, fmt.Errorf("claims type is invalid")
+	}
+	if claims.Subject != string(tokenType) {
+		return nil, fmt.Errorf("token type is invalid")
+	}
+
+	return claims.Claims, nil
+}
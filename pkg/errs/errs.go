@@ -0,0 +1,96 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+)
+
+// The kinds of error a repository or service may return, utils.ErrorStatus
+// maps each of them to an HTTP status. Anything else is an internal error.
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrValidation   = errors.New("validation failed")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+
+	// a conditional write whose If-Match is stale or missing
+	ErrPreconditionFailed   = errors.New("precondition failed")
+	ErrPreconditionRequired = errors.New("precondition required")
+)
+
+// Error is an error of one kind with the message shown to the client,
+// errors.Is(err, ErrNotFound) matches it through any %w wrapping. Fields
+// lists the invalid request fields of a validation error.
+type Error struct {
+	Kind    error
+	Message string
+	Fields  []FieldError
+}
+
+// FieldError names a request field by its json name and why it was rejected.
+type FieldError struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+func (e *Error) Error() string { return e.Message }
+func (e *Error) Unwrap() error { return e.Kind }
+
+func newError(kind error, format string, args ...any) error {
+	return &Error{Kind: kind, Message: fmt.Sprintf(format, args...)}
+}
+
+func NotFound(format string, args ...any) error {
+	return newError(ErrNotFound, format, args...)
+}
+
+func Conflict(format string, args ...any) error {
+	return newError(ErrConflict, format, args...)
+}
+
+func Validation(format string, args ...any) error {
+	return newError(ErrValidation, format, args...)
+}
+
+func Unauthorized(format string, args ...any) error {
+	return newError(ErrUnauthorized, format, args...)
+}
+
+func Forbidden(format string, args ...any) error {
+	return newError(ErrForbidden, format, args...)
+}
+
+func PreconditionFailed(format string, args ...any) error {
+	return newError(ErrPreconditionFailed, format, args...)
+}
+
+func PreconditionRequired(format string, args ...any) error {
+	return newError(ErrPreconditionRequired, format, args...)
+}
+
+// Invalid is a validation error listing each rejected field.
+func Invalid(fields ...FieldError) error {
+	return &Error{Kind: ErrValidation, Message: "request has invalid fields", Fields: fields}
+}
+
+// InvalidField is Invalid with a single field.
+func InvalidField(field, format string, args ...any) error {
+	return Invalid(FieldError{Field: field, Reason: fmt.Sprintf(format, args...)})
+}
+
+// Known reports whether err carries one of the kinds above, services pass
+// those to the handler and replace anything else with a generic message.
+func Known(err error) bool {
+	var e *Error
+	return errors.As(err, &e)
+}
+
+// Fields returns the invalid fields carried by err, if any.
+func Fields(err error) []FieldError {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Fields
+	}
+	return nil
+}
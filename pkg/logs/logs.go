@@ -1,17 +1,23 @@
 package logs
 
 import (
+	"context"
+
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
-var log *zap.Logger
+var (
+	log   *zap.Logger
+	level = zap.NewAtomicLevelAt(zap.InfoLevel)
+)
 
+// init builds the logger used until config.InitLogger replaces it with the
+// one described by the log.* settings.
 func init() {
 	config := zap.NewProductionConfig()
-	config.EncoderConfig.TimeKey = "timestamp"
-	config.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
-	config.EncoderConfig.StacktraceKey = ""
+	config.Level = level
+	config.EncoderConfig = EncoderConfig()
 
 	var err error
 	log, err = config.Build(zap.AddCallerSkip(1))
@@ -20,6 +26,33 @@ func init() {
 	}
 }
 
+// EncoderConfig is the field layout shared by every logger of the app.
+func EncoderConfig() zapcore.EncoderConfig {
+	encoder := zap.NewProductionEncoderConfig()
+	encoder.TimeKey = "timestamp"
+	encoder.EncodeTime = zapcore.ISO8601TimeEncoder
+	encoder.StacktraceKey = ""
+	return encoder
+}
+
+// Replace swaps the package logger, atomic is the level logger was built
+// with so Level can change it at runtime.
+func Replace(logger *zap.Logger, atomic zap.AtomicLevel) {
+	log.Sync()
+	log = logger.WithOptions(zap.AddCallerSkip(1))
+	level = atomic
+}
+
+// Level returns the level of the current logger, SetLevel on it takes
+// effect immediately.
+func Level() zap.AtomicLevel {
+	return level
+}
+
+func Sync() error {
+	return log.Sync()
+}
+
 func Info(message string, fields ...zap.Field) {
 	log.Info(message, fields...)
 }
@@ -28,6 +61,10 @@ func Debug(message string, fields ...zap.Field) {
 	log.Debug(message, fields...)
 }
 
+func Warn(message string, fields ...zap.Field) {
+	log.Warn(message, fields...)
+}
+
 func Error(message any, fields ...zap.Field) {
 	switch v := message.(type) {
 	case error:
@@ -36,3 +73,37 @@ func Error(message any, fields ...zap.Field) {
 		log.Error(v, fields...)
 	}
 }
+
+type contextKey struct{}
+
+// WithRequestId returns a copy of ctx carrying the request id, the
+// *Context functions add it to every entry as the request_id field.
+func WithRequestId(ctx context.Context, requestId string) context.Context {
+	return context.WithValue(ctx, contextKey{}, requestId)
+}
+
+// RequestId returns the request id of ctx, or "" outside a request.
+func RequestId(ctx context.Context) string {
+	requestId, _ := ctx.Value(contextKey{}).(string)
+	return requestId
+}
+
+func withContext(ctx context.Context, fields []zap.Field) []zap.Field {
+	if requestId := RequestId(ctx); requestId != "" {
+		fields = append(fields, zap.String("request_id", requestId))
+	}
+	return fields
+}
+
+func InfoContext(ctx context.Context, message string, fields ...zap.Field) {
+	log.Info(message, withContext(ctx, fields)...)
+}
+
+func ErrorContext(ctx context.Context, message any, fields ...zap.Field) {
+	switch v := message.(type) {
+	case error:
+		log.Error(v.Error(), withContext(ctx, fields)...)
+	case string:
+		log.Error(v, withContext(ctx, fields)...)
+	}
+}
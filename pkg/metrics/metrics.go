@@ -0,0 +1,135 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace string = "sales"
+
+// registry holds only the collectors below plus the Go and process ones, so
+// /metrics doesn't pick up whatever a dependency registers globally.
+var registry = prometheus.NewRegistry()
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by route template, method and status.",
+	}, []string{"route", "method", "status"})
+
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by route template, method and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method", "status"})
+
+	// OrdersCreated counts orders by payment method.
+	OrdersCreated = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "orders_created_total",
+		Help:      "Orders created by payment method.",
+	}, []string{"payment_method"})
+
+	// StockRejections counts orders and stock adjustments refused because a
+	// product didn't have enough stock, source is "order" or "adjustment".
+	StockRejections = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "stock_rejections_total",
+		Help:      "Orders and stock adjustments refused for insufficient stock.",
+	}, []string{"source"})
+
+	// ProductsOutOfStock counts the times an order or a stock adjustment took
+	// a product's stock from above zero to zero, source as above.
+	ProductsOutOfStock = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "products_out_of_stock_total",
+		Help:      "Products whose stock an order or stock adjustment brought to zero.",
+	}, []string{"source"})
+)
+
+// dbStats is the pool collector of the current connection, see RegisterDB.
+var dbStats prometheus.Collector
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests,
+		httpDuration,
+		OrdersCreated,
+		StockRejections,
+		ProductsOutOfStock,
+	)
+}
+
+// RegisterDB exports the DB.Stats() pool gauges of pool, replacing the
+// pool registered before. The memory driver has no pool and passes nil.
+func RegisterDB(pool *sql.DB, driver string) {
+	if dbStats != nil {
+		registry.Unregister(dbStats)
+		dbStats = nil
+	}
+	if pool == nil {
+		return
+	}
+
+	dbStats = collectors.NewDBStatsCollector(pool, driver)
+	registry.MustRegister(dbStats)
+}
+
+// Middleware records every request under its route template, e.g.
+// /v1/products/:productId, so ids don't blow up the label cardinality.
+// Requests that match no route are recorded as "unmatched".
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		labels := prometheus.Labels{
+			"route":  route,
+			"method": method(c.Request.Method),
+			"status": strconv.Itoa(c.Writer.Status()),
+		}
+
+		httpRequests.With(labels).Inc()
+		httpDuration.With(labels).Observe(time.Since(start).Seconds())
+	}
+}
+
+// knownMethods are the methods recorded by name, a client can send any
+// token as the method so everything else shares the label "other".
+var knownMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodPost:    true,
+	http.MethodPut:     true,
+	http.MethodPatch:   true,
+	http.MethodDelete:  true,
+	http.MethodOptions: true,
+}
+
+func method(m string) string {
+	if knownMethods[m] {
+		return m
+	}
+	return "other"
+}
+
+// Handler serves the registry in the Prometheus text format.
+func Handler() gin.HandlerFunc {
+	h := promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+	return gin.WrapH(h)
+}
@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/codepnw/sales-api/pkg/errs"
+)
+
+// ErrorStatus picks the status code for an error returned by a service.
+func ErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, errs.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, errs.ErrConflict):
+		return http.StatusConflict
+	case errors.Is(err, errs.ErrValidation):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, errs.ErrUnauthorized):
+		return http.StatusUnauthorized
+	case errors.Is(err, errs.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, errs.ErrPreconditionFailed):
+		return http.StatusPreconditionFailed
+	case errors.Is(err, errs.ErrPreconditionRequired):
+		return http.StatusPreconditionRequired
+	}
+	return http.StatusInternalServerError
+}
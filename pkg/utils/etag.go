@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/codepnw/sales-api/pkg/errs"
+	"github.com/gin-gonic/gin"
+)
+
+// AnyVersion is what IfMatch returns for "If-Match: *", the write goes
+// ahead as long as the row exists.
+const AnyVersion int = 0
+
+// ETag formats a row version as a strong entity tag, e.g. "3".
+func ETag(version int) string {
+	return strconv.Quote(strconv.Itoa(version))
+}
+
+// IfMatch returns the version a conditional write expects, a missing header
+// is a 428 and a tag that can't be one of our versions a 412. Only a single
+// strong tag or * is accepted.
+func IfMatch(c *gin.Context) (int, error) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" {
+		return 0, errs.PreconditionRequired("If-Match header is required")
+	}
+	if header == "*" {
+		return AnyVersion, nil
+	}
+
+	tag, ok := strings.CutPrefix(header, `"`)
+	if ok {
+		tag, ok = strings.CutSuffix(tag, `"`)
+	}
+	if !ok {
+		return 0, errs.PreconditionFailed("If-Match %s is not a current entity tag", header)
+	}
+	version, err := strconv.Atoi(tag)
+	if err != nil || version <= AnyVersion {
+		return 0, errs.PreconditionFailed("If-Match %s is not a current entity tag", header)
+	}
+
+	return version, nil
+}
+
+// NotModified reports whether If-None-Match lists etag, GET handlers answer
+// 304 then. Weak tags compare equal to the strong one.
+func NotModified(c *gin.Context, etag string) bool {
+	header := c.GetHeader("If-None-Match")
+	if header == "" {
+		return false
+	}
+
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == etag {
+			return true
+		}
+	}
+	return false
+}
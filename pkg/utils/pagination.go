@@ -0,0 +1,44 @@
+package utils
+
+const (
+	DefaultPage  int = 1
+	DefaultLimit int = 20
+	MaxLimit     int = 100
+)
+
+type PageQuery struct {
+	Page  int `form:"page"`
+	Limit int `form:"limit"`
+}
+
+type Pagination struct {
+	Page       int `json:"page"`
+	Limit      int `json:"limit"`
+	TotalItems int `json:"totalItems"`
+	TotalPages int `json:"totalPages"`
+}
+
+func (q *PageQuery) Normalize() {
+	if q.Page < 1 {
+		q.Page = DefaultPage
+	}
+	if q.Limit < 1 {
+		q.Limit = DefaultLimit
+	}
+	if q.Limit > MaxLimit {
+		q.Limit = MaxLimit
+	}
+}
+
+func (q *PageQuery) Offset() int {
+	return (q.Page - 1) * q.Limit
+}
+
+func NewPagination(q PageQuery, totalItems int) *Pagination {
+	return &Pagination{
+		Page:       q.Page,
+		Limit:      q.Limit,
+		TotalItems: totalItems,
+		TotalPages: (totalItems + q.Limit - 1) / q.Limit,
+	}
+}
@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// MIMEMergePatch is the RFC 7396 media type, PATCH also accepts the same
+// document sent as plain application/json.
+const MIMEMergePatch string = "application/merge-patch+json"
+
+// ShouldBindMergePatch applies the request body as an RFC 7396 merge patch to
+// target, which holds the current values, and validates the result like
+// ShouldBindJSON. Absent fields keep their value, null resets a field to its
+// zero value and anything else, 0 and "" included, is written as sent.
+func ShouldBindMergePatch(c *gin.Context, target any) error {
+	patch, err := c.GetRawData()
+	if err != nil {
+		return err
+	}
+
+	var changes any
+	if err := json.Unmarshal(patch, &changes); err != nil {
+		return err
+	}
+	if _, ok := changes.(map[string]any); !ok {
+		return errors.New("merge patch must be a json object")
+	}
+
+	current, err := json.Marshal(target)
+	if err != nil {
+		return err
+	}
+	var doc any
+	if err := json.Unmarshal(current, &doc); err != nil {
+		return err
+	}
+
+	merged, err := json.Marshal(mergePatch(doc, changes))
+	if err != nil {
+		return err
+	}
+
+	// start from zero so the fields the patch removed don't keep their value
+	value := reflect.ValueOf(target).Elem()
+	value.SetZero()
+	if err := json.Unmarshal(merged, target); err != nil {
+		return err
+	}
+
+	return binding.Validator.ValidateStruct(target)
+}
+
+// mergePatch is the MergePatch function of RFC 7396 section 2.
+func mergePatch(target, patch any) any {
+	changes, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	doc, ok := target.(map[string]any)
+	if !ok {
+		doc = map[string]any{}
+	}
+	for name, value := range changes {
+		if value == nil {
+			delete(doc, name)
+			continue
+		}
+		doc[name] = mergePatch(doc[name], value)
+	}
+	return doc
+}
@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/codepnw/sales-api/pkg/logs"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const HeaderRequestId string = "X-Request-ID"
+
+// requestIdPattern limits what a client may send as X-Request-ID, anything
+// else is replaced so it can't forge log lines.
+var requestIdPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// RequestId accepts the X-Request-ID of the caller or generates one, echoes
+// it in the response and stores it in the request context for pkg/logs.
+func RequestId() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestId := c.GetHeader(HeaderRequestId)
+		if !requestIdPattern.MatchString(requestId) {
+			requestId = uuid.NewString()
+		}
+
+		c.Request = c.Request.WithContext(logs.WithRequestId(c.Request.Context(), requestId))
+		c.Header(HeaderRequestId, requestId)
+		c.Next()
+	}
+}
+
+// AccessLog writes one structured entry per request in place of the text
+// logger of gin.Default, server errors are logged at error level.
+func AccessLog() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		path := c.Request.URL.Path
+		c.Next()
+
+		status := c.Writer.Status()
+		fields := []zap.Field{
+			zap.Int("status", status),
+			zap.String("method", c.Request.Method),
+			zap.String("path", path),
+			zap.String("route", c.FullPath()),
+			zap.Duration("latency", time.Since(start)),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_agent", c.Request.UserAgent()),
+			zap.Int("size", c.Writer.Size()),
+		}
+		if len(c.Errors) > 0 {
+			fields = append(fields, zap.String("errors", c.Errors.String()))
+		}
+
+		ctx := c.Request.Context()
+		if status >= http.StatusInternalServerError {
+			logs.ErrorContext(ctx, "request", fields...)
+			return
+		}
+		logs.InfoContext(ctx, "request", fields...)
+	}
+}
@@ -1,24 +1,53 @@
 package utils
 
 import (
+	"errors"
+	"net/http"
+
+	"github.com/codepnw/sales-api/pkg/errs"
+	"github.com/codepnw/sales-api/pkg/logs"
 	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
 )
 
+// MIMEProblemJSON is the RFC 7807 media type, clients that accept it get
+// errors as a problem document instead of the {"error":...} envelope.
+const MIMEProblemJSON string = "application/problem+json"
+
 type IResponse interface {
 	Success(code int, data any)
+	SuccessWithPagination(code int, data any, pagination *Pagination)
 	Error(code int, traceId, message string)
+	Fail(traceId string, err error)
+	BindError(traceId string, err error)
 }
 
 type response struct {
 	StatusCode int
 	Data       any
+	Pagination *Pagination
 	ErrorRes   *responseError
 	Context    *gin.Context
 }
 
 type responseError struct {
-	TraceId string `json:"trace_id"`
-	Message string `json:"message"`
+	TraceId   string            `json:"trace_id"`
+	RequestId string            `json:"request_id,omitempty"`
+	Message   string            `json:"message"`
+	Fields    []errs.FieldError `json:"fields,omitempty"`
+}
+
+// problem is an RFC 7807 problem document, Code carries the module error
+// code that the envelope sends as trace_id.
+type problem struct {
+	Type      string            `json:"type"`
+	Title     string            `json:"title"`
+	Status    int               `json:"status"`
+	Detail    string            `json:"detail"`
+	Instance  string            `json:"instance"`
+	Code      string            `json:"code"`
+	RequestId string            `json:"requestId,omitempty"`
+	Fields    []errs.FieldError `json:"fields,omitempty"`
 }
 
 func NewResponse(c *gin.Context) IResponse {
@@ -31,11 +60,63 @@ func (r *response) Success(code int, data any) {
 	r.Context.JSON(r.StatusCode, gin.H{"data": r.Data})
 }
 
+func (r *response) SuccessWithPagination(code int, data any, pagination *Pagination) {
+	r.StatusCode = code
+	r.Data = data
+	r.Pagination = pagination
+	r.Context.JSON(r.StatusCode, gin.H{"data": r.Data, "pagination": r.Pagination})
+}
+
 func (r *response) Error(code int, traceId, message string) {
+	r.writeError(code, traceId, message, nil)
+}
+
+// Fail writes an error returned by a service with the status ErrorStatus
+// picks, a validation error also lists its invalid fields.
+func (r *response) Fail(traceId string, err error) {
+	r.writeError(ErrorStatus(err), traceId, err.Error(), errs.Fields(err))
+}
+
+// BindError writes the error of ShouldBindJSON or ShouldBindQuery, failed
+// binding rules become a 422 listing each field and a malformed body a 400.
+func (r *response) BindError(traceId string, err error) {
+	var invalid validator.ValidationErrors
+	if errors.As(err, &invalid) {
+		r.Fail(traceId, errs.Invalid(fieldErrors(invalid)...))
+		return
+	}
+	r.Error(http.StatusBadRequest, traceId, err.Error())
+}
+
+func (r *response) writeError(code int, traceId, message string, fields []errs.FieldError) {
 	r.StatusCode = code
 	r.ErrorRes = &responseError{
-		TraceId: traceId,
-		Message: message,
+		TraceId:   traceId,
+		RequestId: logs.RequestId(r.Context.Request.Context()),
+		Message:   message,
+		Fields:    fields,
+	}
+
+	r.Context.Header("Vary", "Accept")
+	if r.Context.NegotiateFormat(gin.MIMEJSON, MIMEProblemJSON) == MIMEProblemJSON {
+		r.Context.Header("Content-Type", MIMEProblemJSON)
+		r.Context.JSON(r.StatusCode, r.problem())
+		return
 	}
 	r.Context.JSON(r.StatusCode, gin.H{"error": r.ErrorRes})
 }
+
+// problem renders ErrorRes as a problem document, we don't publish pages
+// per error so type is about:blank and title the status text.
+func (r *response) problem() *problem {
+	return &problem{
+		Type:      "about:blank",
+		Title:     http.StatusText(r.StatusCode),
+		Status:    r.StatusCode,
+		Detail:    r.ErrorRes.Message,
+		Instance:  r.Context.Request.URL.RequestURI(),
+		Code:      r.ErrorRes.TraceId,
+		RequestId: r.ErrorRes.RequestId,
+		Fields:    r.ErrorRes.Fields,
+	}
+}
@@ -0,0 +1,105 @@
+package utils
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/codepnw/sales-api/pkg/errs"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// init makes the validator report fields by their json or form name, so the
+// 422 response names the field the client actually sent.
+func init() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		for _, tag := range []string{"json", "form"} {
+			name := strings.Split(field.Tag.Get(tag), ",")[0]
+			if name == "-" {
+				return ""
+			}
+			if name != "" {
+				return name
+			}
+		}
+		return field.Name
+	})
+}
+
+func fieldErrors(invalid validator.ValidationErrors) []errs.FieldError {
+	fields := make([]errs.FieldError, 0, len(invalid))
+	for _, fe := range invalid {
+		fields = append(fields, errs.FieldError{Field: fieldPath(fe), Reason: reason(fe)})
+	}
+	return fields
+}
+
+// fieldPath names a nested field from the top of the request, e.g.
+// items[1].quantity, the namespace starts with the struct name.
+func fieldPath(fe validator.FieldError) string {
+	_, path, ok := strings.Cut(fe.Namespace(), ".")
+	if !ok {
+		return fe.Field()
+	}
+	return path
+}
+
+// reason words the binding rules used by the request structs.
+func reason(fe validator.FieldError) string {
+	isString := fe.Kind() == reflect.String
+	isList := fe.Kind() == reflect.Slice || fe.Kind() == reflect.Map
+
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be a valid email"
+	case "uuid":
+		return "must be a valid uuid"
+	case "oneof":
+		return "must be one of " + fe.Param()
+	case "required_unless":
+		field, value, _ := strings.Cut(fe.Param(), " ")
+		return fmt.Sprintf("is required unless %s is %s", lowerFirst(field), value)
+	case "min":
+		if isString {
+			return fmt.Sprintf("must be at least %s characters", fe.Param())
+		}
+		if isList {
+			return fmt.Sprintf("must have at least %s items", fe.Param())
+		}
+		return "must be at least " + fe.Param()
+	case "max":
+		if isString {
+			return fmt.Sprintf("must be at most %s characters", fe.Param())
+		}
+		return "must be at most " + fe.Param()
+	case "gt":
+		return "must be greater than " + fe.Param()
+	case "gte":
+		return "must be at least " + fe.Param()
+	case "lte":
+		return "must be at most " + fe.Param()
+	case "ltefield":
+		return "must not be greater than " + lowerFirst(fe.Param())
+	}
+	return fmt.Sprintf("failed the %s rule", fe.Tag())
+}
+
+// lowerFirst turns the struct field a cross-field rule points at, e.g.
+// Price, into its json name.
+func lowerFirst(name string) string {
+	if name == "" {
+		return name
+	}
+	r := []rune(name)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
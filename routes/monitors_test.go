@@ -0,0 +1,93 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/codepnw/sales-api/database"
+	"github.com/codepnw/sales-api/modules/categories"
+	"github.com/codepnw/sales-api/modules/inventories"
+	"github.com/codepnw/sales-api/modules/monitors"
+	"github.com/codepnw/sales-api/pkg/logs"
+)
+
+func TestMonitorRoutes(t *testing.T) {
+	s := newTestServer(t)
+
+	health := monitors.Health{}
+	s.ok(http.StatusOK, http.MethodGet, "/healthz", "", nil, &health)
+	if health.Status != "ok" {
+		t.Fatalf("healthz returned %+v", health)
+	}
+
+	ready := database.Readiness{}
+	s.ok(http.StatusOK, http.MethodGet, "/readyz", "", nil, &ready)
+	onDisk := testDriver != database.DriverMemory
+	if ready.Driver != testDriver || (ready.Pool != nil) != onDisk || (ready.MigrationVersion > 0) != onDisk {
+		t.Fatalf("readyz returned %+v", ready)
+	}
+
+	info := monitors.BuildInfo{}
+	s.ok(http.StatusOK, http.MethodGet, "/version", "", nil, &info)
+	if info.Version != "/v1" || info.Commit == "" || info.GoVersion == "" {
+		t.Fatalf("version returned %+v", info)
+	}
+}
+
+func TestMetricsRoute(t *testing.T) {
+	s := newTestServer(t)
+	employee := s.signIn(roleEmployee)
+	admin := s.signIn(roleAdmin)
+
+	s.fail(http.StatusNotFound, "products-002", http.MethodGet, "/v1/products/P999999", employee, nil)
+	s.router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("BREW", "/v1/products", nil))
+
+	// damaging the last unit sells the product out
+	s.ok(http.StatusCreated, http.MethodPost, "/v1/categories/", admin, categories.Category{Title: "food"}, nil)
+	s.ok(http.StatusCreated, http.MethodPost, "/v1/products/", admin, map[string]any{"name": "Tea", "price": 1, "stock": 1, "categoryId": 1}, nil)
+	damage := inventories.StockAdjustmentRequest{Type: inventories.AdjustDamage, Quantity: 1}
+	s.ok(http.StatusCreated, http.MethodPost, "/v1/products/P000001/stock", admin, damage, nil)
+
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("metrics status %d", rec.Code)
+	}
+
+	for _, want := range []string{
+		`sales_http_requests_total{method="GET",route="/v1/products/:productId",status="404"}`,
+		`method="other"`,
+		`sales_products_out_of_stock_total{source="adjustment"}`,
+	} {
+		if !strings.Contains(rec.Body.String(), want) {
+			t.Fatalf("metrics has no %s", want)
+		}
+	}
+	if strings.Contains(rec.Body.String(), `method="BREW"`) {
+		t.Fatal("metrics labels an unknown method by name")
+	}
+}
+
+func TestLogLevelRoutes(t *testing.T) {
+	s := newTestServer(t)
+	admin := s.signIn(roleAdmin)
+	superAdmin := s.signIn(roleSuperAdmin)
+	defer logs.Level().SetLevel(logs.Level().Level())
+
+	level := monitors.LogLevel{}
+	s.ok(http.StatusOK, http.MethodGet, "/v1/admin/log-level", superAdmin, nil, &level)
+	if level.Level != "info" {
+		t.Fatalf("log level is %q, want info", level.Level)
+	}
+
+	s.ok(http.StatusOK, http.MethodPut, "/v1/admin/log-level", superAdmin, monitors.LogLevel{Level: "debug"}, &level)
+	if level.Level != "debug" || logs.Level().String() != "debug" {
+		t.Fatalf("log level is %q, want debug", logs.Level().String())
+	}
+
+	s.fail(http.StatusUnprocessableEntity, "monitors-002", http.MethodPut, "/v1/admin/log-level", superAdmin, monitors.LogLevel{Level: "loud"})
+	s.fail(http.StatusBadRequest, "monitors-002", http.MethodPut, "/v1/admin/log-level", superAdmin, "{")
+	s.fail(http.StatusForbidden, "middlewares-002", http.MethodPut, "/v1/admin/log-level", admin, monitors.LogLevel{Level: "warn"})
+}
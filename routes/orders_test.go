@@ -0,0 +1,281 @@
+package routes
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/codepnw/sales-api/modules/categories"
+	"github.com/codepnw/sales-api/modules/customers"
+	"github.com/codepnw/sales-api/modules/inventories"
+	"github.com/codepnw/sales-api/modules/orders"
+	"github.com/codepnw/sales-api/modules/payments"
+	"github.com/codepnw/sales-api/modules/products"
+)
+
+func TestCustomerRoutes(t *testing.T) {
+	s := newTestServer(t)
+	employee := s.signIn(roleEmployee)
+	admin := s.signIn(roleAdmin)
+
+	req := customers.CustomerRequest{FirstName: "Somchai", LastName: "Jaidee", Phone: "0812345678", Email: "somchai@mail.com", Address: "Bangkok"}
+	customer := customers.Customer{}
+	s.ok(http.StatusCreated, http.MethodPost, "/v1/customers/", employee, req, &customer)
+	if customer.CustomerId == "" || customer.Phone != req.Phone {
+		t.Fatalf("create returned %+v", customer)
+	}
+	s.fail(http.StatusConflict, "customers-001", http.MethodPost, "/v1/customers/", employee, req)
+	env := s.fail(http.StatusUnprocessableEntity, "customers-001", http.MethodPost, "/v1/customers/", employee, customers.CustomerRequest{FirstName: "A", Email: "a.mail.com"})
+	s.fields(env, "lastName", "phone", "email", "address")
+	s.fail(http.StatusBadRequest, "customers-001", http.MethodPost, "/v1/customers/", employee, "{")
+
+	other := customers.CustomerRequest{FirstName: "Somsri", LastName: "Jaidee", Phone: "0899999999", Email: "somsri@mail.com", Address: "Chiang Mai"}
+	s.ok(http.StatusCreated, http.MethodPost, "/v1/customers/", employee, other, nil)
+
+	all := make([]*customers.Customer, 0)
+	s.ok(http.StatusOK, http.MethodGet, "/v1/customers/", employee, nil, &all)
+	if len(all) != 2 || all[0].CustomerId != customer.CustomerId {
+		t.Fatalf("list returned %+v", all)
+	}
+
+	path := "/v1/customers/" + customer.CustomerId
+	s.ok(http.StatusOK, http.MethodGet, path, employee, nil, &customer)
+	s.fail(http.StatusNotFound, "customers-002", http.MethodGet, "/v1/customers/C999999", employee, nil)
+
+	s.ok(http.StatusOK, http.MethodGet, "/v1/customers/phone/"+req.Phone, employee, nil, &customer)
+	s.fail(http.StatusNotFound, "customers-006", http.MethodGet, "/v1/customers/phone/0000000000", employee, nil)
+	s.ok(http.StatusOK, http.MethodGet, "/v1/customers/email/"+req.Email, employee, nil, &customer)
+	s.fail(http.StatusNotFound, "customers-007", http.MethodGet, "/v1/customers/email/nobody@mail.com", employee, nil)
+
+	s.ok(http.StatusOK, http.MethodPatch, path, employee, map[string]any{"address": "Phuket"}, &customer)
+	if customer.Address != "Phuket" || customer.FirstName != req.FirstName {
+		t.Fatalf("update returned %+v", customer)
+	}
+	// the merged customer is validated like a new one
+	env = s.fail(http.StatusUnprocessableEntity, "customers-004", http.MethodPatch, path, employee, map[string]any{"address": "", "email": "somchai"})
+	s.fields(env, "email", "address")
+	s.fail(http.StatusConflict, "customers-004", http.MethodPatch, path, employee, map[string]any{"phone": other.Phone})
+	s.fail(http.StatusBadRequest, "customers-004", http.MethodPatch, path, employee, "{")
+	s.fail(http.StatusNotFound, "customers-004", http.MethodPatch, "/v1/customers/C999999", employee, map[string]any{"address": "Phuket"})
+
+	s.fail(http.StatusForbidden, "middlewares-002", http.MethodDelete, path, employee, nil)
+	s.ok(http.StatusNoContent, http.MethodDelete, path, admin, nil, nil)
+	s.fail(http.StatusNotFound, "customers-005", http.MethodDelete, path, admin, nil)
+}
+
+// newShop creates a customer and two products, P000001 with 10 in stock at
+// 100 less 10 discount and P000002 with 2 in stock at 50.
+func newShop(t *testing.T, s *testServer, admin string) {
+	t.Helper()
+
+	s.ok(http.StatusCreated, http.MethodPost, "/v1/categories/", admin, categories.Category{Title: "food"}, nil)
+	s.ok(http.StatusCreated, http.MethodPost, "/v1/products/", admin, products.ProductRequest{Name: "Coffee", Price: 100, Discount: 10, CategoryID: 1}, nil)
+	s.ok(http.StatusCreated, http.MethodPost, "/v1/products/", admin, products.ProductRequest{Name: "Cake", Price: 50, CategoryID: 1}, nil)
+
+	recount := func(productId string, quantity int) {
+		req := inventories.StockAdjustmentRequest{Type: inventories.AdjustRecount, Quantity: quantity}
+		s.ok(http.StatusCreated, http.MethodPost, "/v1/products/"+productId+"/stock", admin, req, nil)
+	}
+	recount("P000001", 10)
+	recount("P000002", 2)
+
+	customer := customers.CustomerRequest{FirstName: "Somchai", LastName: "Jaidee", Phone: "0812345678", Email: "somchai@mail.com", Address: "Bangkok"}
+	s.ok(http.StatusCreated, http.MethodPost, "/v1/customers/", admin, customer, nil)
+}
+
+func stockOf(t *testing.T, s *testServer, token, productId string) uint {
+	t.Helper()
+
+	product := products.Product{}
+	s.ok(http.StatusOK, http.MethodGet, "/v1/products/"+productId, token, nil, &product)
+	return product.Stock
+}
+
+func TestOrderRoutes(t *testing.T) {
+	s := newTestServer(t)
+	employee := s.signIn(roleEmployee)
+	newShop(t, s, s.signIn(roleAdmin))
+
+	req := orders.OrderRequest{
+		CustomerId:    "C000001",
+		PaymentMethod: orders.PaymentCash,
+		Items: []*orders.OrderItemRequest{
+			{ProductId: "P000001", Quantity: 2},
+			{ProductId: "P000002", Quantity: 1},
+			{ProductId: "P000001", Quantity: 1},
+		},
+	}
+	order := orders.Order{}
+	s.ok(http.StatusCreated, http.MethodPost, "/v1/orders/", employee, req, &order)
+	if order.OrderId == "" || order.Status != orders.StatusWaiting || order.TotalAmount != 320 || len(order.Items) != 2 {
+		t.Fatalf("create returned %+v", order)
+	}
+	if stock := stockOf(t, s, employee, "P000001"); stock != 7 {
+		t.Fatalf("P000001 stock is %d, want 7", stock)
+	}
+
+	// a failed item leaves every product untouched
+	short := orders.OrderRequest{
+		CustomerId:    "C000001",
+		PaymentMethod: orders.PaymentCash,
+		Items:         []*orders.OrderItemRequest{{ProductId: "P000001", Quantity: 1}, {ProductId: "P000002", Quantity: 5}},
+	}
+	s.fail(http.StatusConflict, "orders-001", http.MethodPost, "/v1/orders/", employee, short)
+	if stock := stockOf(t, s, employee, "P000001"); stock != 7 {
+		t.Fatalf("P000001 stock is %d after a failed order, want 7", stock)
+	}
+
+	env := s.fail(http.StatusUnprocessableEntity, "orders-001", http.MethodPost, "/v1/orders/", employee, orders.OrderRequest{PaymentMethod: "cheque"})
+	s.fields(env, "customerId", "paymentMethod", "items")
+	env = s.fail(http.StatusUnprocessableEntity, "orders-001", http.MethodPost, "/v1/orders/", employee, orders.OrderRequest{
+		CustomerId:    "C000001",
+		PaymentMethod: orders.PaymentCash,
+		Items:         []*orders.OrderItemRequest{{ProductId: "P000001", Quantity: 1}, {Quantity: 0}},
+	})
+	s.fields(env, "items[1].productId", "items[1].quantity")
+	s.fail(http.StatusUnprocessableEntity, "orders-001", http.MethodPost, "/v1/orders/", employee, orders.OrderRequest{
+		CustomerId:    "C999999",
+		PaymentMethod: orders.PaymentCash,
+		Items:         []*orders.OrderItemRequest{{ProductId: "P000001", Quantity: 1}},
+	})
+	s.fail(http.StatusBadRequest, "orders-001", http.MethodPost, "/v1/orders/", employee, "{")
+
+	all := make([]*orders.Order, 0)
+	s.ok(http.StatusOK, http.MethodGet, "/v1/orders/", employee, nil, &all)
+	if len(all) != 1 {
+		t.Fatalf("list returned %d orders, want 1", len(all))
+	}
+
+	path := "/v1/orders/" + order.OrderId
+	s.ok(http.StatusOK, http.MethodGet, path, employee, nil, &order)
+	if len(order.Items) != 2 || order.Items[0].ProductId != "P000001" || order.Items[0].Quantity != 3 {
+		t.Fatalf("get returned %+v", order)
+	}
+	s.fail(http.StatusNotFound, "orders-002", http.MethodGet, "/v1/orders/O999999", employee, nil)
+
+	s.fail(http.StatusBadRequest, "orders-004", http.MethodPatch, path+"/status", employee, "{")
+	s.fail(http.StatusNotFound, "orders-004", http.MethodPatch, "/v1/orders/O999999/status", employee, orders.OrderStatusRequest{Status: orders.StatusCancel})
+
+	s.ok(http.StatusOK, http.MethodPatch, path+"/status", employee, orders.OrderStatusRequest{Status: orders.StatusCancel}, &order)
+	if order.Status != orders.StatusCancel {
+		t.Fatalf("status returned %+v", order)
+	}
+	if stock := stockOf(t, s, employee, "P000001"); stock != 10 {
+		t.Fatalf("P000001 stock is %d after cancel, want 10", stock)
+	}
+
+	s.fail(http.StatusConflict, "orders-005", http.MethodPatch, path+"/status", employee, orders.OrderStatusRequest{Status: orders.StatusCompleted})
+}
+
+func TestConcurrentOrders(t *testing.T) {
+	s := newTestServer(t)
+	employee := s.signIn(roleEmployee)
+	newShop(t, s, s.signIn(roleAdmin))
+
+	body, _ := json.Marshal(orders.OrderRequest{
+		CustomerId:    "C000001",
+		PaymentMethod: orders.PaymentCash,
+		Items:         []*orders.OrderItemRequest{{ProductId: "P000002", Quantity: 1}},
+	})
+
+	// P000002 has 2 in stock, so exactly 2 of the orders can go through
+	codes := make(chan int, 20)
+	var wg sync.WaitGroup
+	for i := 0; i < cap(codes); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			req := httptest.NewRequest(http.MethodPost, "/v1/orders/", bytes.NewReader(body))
+			req.Header.Set("Authorization", "Bearer "+employee)
+			rec := httptest.NewRecorder()
+			s.router.ServeHTTP(rec, req)
+			codes <- rec.Code
+		}()
+	}
+	wg.Wait()
+	close(codes)
+
+	created := 0
+	for code := range codes {
+		switch code {
+		case http.StatusCreated:
+			created++
+		case http.StatusConflict:
+		default:
+			t.Fatalf("order returned status %d", code)
+		}
+	}
+	if created != 2 {
+		t.Fatalf("%d orders created, want 2", created)
+	}
+	if stock := stockOf(t, s, employee, "P000002"); stock != 0 {
+		t.Fatalf("P000002 stock is %d, want 0", stock)
+	}
+}
+
+func TestPaymentRoutes(t *testing.T) {
+	s := newTestServer(t)
+	employee := s.signIn(roleEmployee)
+	newShop(t, s, s.signIn(roleAdmin))
+
+	req := orders.OrderRequest{
+		CustomerId:    "C000001",
+		PaymentMethod: orders.PaymentTransfer,
+		Items:         []*orders.OrderItemRequest{{ProductId: "P000002", Quantity: 2}},
+	}
+	order := orders.Order{}
+	s.ok(http.StatusCreated, http.MethodPost, "/v1/orders/", employee, req, &order)
+	path := "/v1/orders/" + order.OrderId
+
+	balance := payments.Balance{}
+	s.ok(http.StatusOK, http.MethodGet, path+"/balance", employee, nil, &balance)
+	if balance.TotalAmount != 100 || balance.Outstanding != 100 {
+		t.Fatalf("balance returned %+v", balance)
+	}
+	s.fail(http.StatusNotFound, "payments-003", http.MethodGet, "/v1/orders/O999999/balance", employee, nil)
+
+	payment := payments.Payment{}
+	s.ok(http.StatusCreated, http.MethodPost, path+"/payments", employee, payments.PaymentRequest{Amount: 40, PaymentMethod: orders.PaymentTransfer}, &payment)
+	if payment.PaymentId == "" || payment.Amount != 40 {
+		t.Fatalf("payment returned %+v", payment)
+	}
+	s.fail(http.StatusConflict, "orders-005", http.MethodPatch, path+"/status", employee, orders.OrderStatusRequest{Status: orders.StatusCompleted})
+
+	s.fail(http.StatusConflict, "payments-001", http.MethodPost, path+"/payments", employee, payments.PaymentRequest{Amount: 80, PaymentMethod: orders.PaymentTransfer})
+	env := s.fail(http.StatusUnprocessableEntity, "payments-001", http.MethodPost, path+"/payments", employee, payments.PaymentRequest{Amount: 0, PaymentMethod: "BITCOIN"})
+	s.fields(env, "amount", "paymentMethod")
+	s.fail(http.StatusNotFound, "payments-001", http.MethodPost, "/v1/orders/O999999/payments", employee, payments.PaymentRequest{Amount: 1, PaymentMethod: orders.PaymentCash})
+	s.fail(http.StatusBadRequest, "payments-001", http.MethodPost, path+"/payments", employee, "{")
+
+	// cash above the outstanding amount is settled with change
+	s.ok(http.StatusCreated, http.MethodPost, path+"/payments", employee, payments.PaymentRequest{Amount: 100, PaymentMethod: orders.PaymentCash}, &payment)
+	if payment.Amount != 60 || payment.Change != 40 {
+		t.Fatalf("payment returned %+v", payment)
+	}
+
+	s.ok(http.StatusOK, http.MethodGet, path+"/balance", employee, nil, &balance)
+	if balance.Status != orders.StatusCompleted || balance.Outstanding != 0 || balance.PaidAmount != 100 {
+		t.Fatalf("balance returned %+v", balance)
+	}
+	s.fail(http.StatusConflict, "payments-001", http.MethodPost, path+"/payments", employee, payments.PaymentRequest{Amount: 1, PaymentMethod: orders.PaymentCash})
+
+	pays := make([]*payments.Payment, 0)
+	s.ok(http.StatusOK, http.MethodGet, path+"/payments", employee, nil, &pays)
+	if len(pays) != 2 {
+		t.Fatalf("list returned %d payments, want 2", len(pays))
+	}
+
+	// cancelling a partly paid order reports what has to be given back
+	req.Items = []*orders.OrderItemRequest{{ProductId: "P000001", Quantity: 1}}
+	s.ok(http.StatusCreated, http.MethodPost, "/v1/orders/", employee, req, &order)
+	path = "/v1/orders/" + order.OrderId
+	s.ok(http.StatusCreated, http.MethodPost, path+"/payments", employee, payments.PaymentRequest{Amount: 30, PaymentMethod: orders.PaymentTransfer}, nil)
+	s.ok(http.StatusOK, http.MethodPatch, path+"/status", employee, orders.OrderStatusRequest{Status: orders.StatusCancel}, &order)
+	if order.Status != orders.StatusCancel || order.RefundDue != 30 {
+		t.Fatalf("status returned %+v", order)
+	}
+}
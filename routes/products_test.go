@@ -0,0 +1,300 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/codepnw/sales-api/modules/categories"
+	"github.com/codepnw/sales-api/modules/inventories"
+	"github.com/codepnw/sales-api/modules/products"
+	"github.com/codepnw/sales-api/pkg/errs"
+)
+
+func TestCategoryRoutes(t *testing.T) {
+	s := newTestServer(t)
+	employee := s.signIn(roleEmployee)
+	admin := s.signIn(roleAdmin)
+
+	category := categories.Category{}
+	s.ok(http.StatusCreated, http.MethodPost, "/v1/categories/", admin, categories.Category{Title: "gadget", Desc: "just a gadget"}, &category)
+	if category.CategoryId == 0 {
+		t.Fatalf("create returned %+v", category)
+	}
+	s.fail(http.StatusBadRequest, "category-001", http.MethodPost, "/v1/categories/", admin, "{")
+	s.fail(http.StatusConflict, "category-001", http.MethodPost, "/v1/categories/", admin, categories.Category{Title: "gadget"})
+	s.fail(http.StatusUnprocessableEntity, "category-001", http.MethodPost, "/v1/categories/", admin, categories.Category{Title: strings.Repeat("x", 256)})
+
+	all := make([]*categories.Category, 0)
+	s.ok(http.StatusOK, http.MethodGet, "/v1/categories/", employee, nil, &all)
+	if len(all) != 1 {
+		t.Fatalf("got %d categories, want 1", len(all))
+	}
+
+	s.ok(http.StatusOK, http.MethodGet, "/v1/categories/1", employee, nil, &category)
+	s.fail(http.StatusNotFound, "category-002", http.MethodGet, "/v1/categories/99", employee, nil)
+
+	s.ok(http.StatusOK, http.MethodPatch, "/v1/categories/1", admin, map[string]any{"title": "gadgets"}, &category)
+	if category.Title != "gadgets" || category.Desc != "just a gadget" {
+		t.Fatalf("update returned %+v", category)
+	}
+	s.ok(http.StatusOK, http.MethodPatch, "/v1/categories/1", admin, map[string]any{"desc": nil}, &category)
+	if category.Title != "gadgets" || category.Desc != "" {
+		t.Fatalf("update returned %+v", category)
+	}
+	s.fail(http.StatusBadRequest, "category-004", http.MethodPatch, "/v1/categories/1", admin, "{")
+	s.fail(http.StatusBadRequest, "category-004", http.MethodPatch, "/v1/categories/1", admin, `["title"]`)
+	s.fail(http.StatusUnprocessableEntity, "category-004", http.MethodPatch, "/v1/categories/1", admin, map[string]any{"title": ""})
+	s.fail(http.StatusNotFound, "category-004", http.MethodPatch, "/v1/categories/99", admin, map[string]any{"title": "x"})
+
+	s.ok(http.StatusOK, http.MethodPut, "/v1/categories/1", admin, categories.CategoryRequest{Title: "gadget", Desc: "replaced"}, &category)
+	if category.Title != "gadget" || category.Desc != "replaced" {
+		t.Fatalf("replace returned %+v", category)
+	}
+	s.fail(http.StatusUnprocessableEntity, "category-006", http.MethodPut, "/v1/categories/1", admin, categories.CategoryRequest{Desc: "no title"})
+	s.fail(http.StatusNotFound, "category-006", http.MethodPut, "/v1/categories/99", admin, categories.CategoryRequest{Title: "x"})
+
+	s.ok(http.StatusNoContent, http.MethodDelete, "/v1/categories/1", admin, nil, nil)
+	s.fail(http.StatusNotFound, "category-005", http.MethodDelete, "/v1/categories/1", admin, nil)
+	s.fail(http.StatusNotFound, "category-002", http.MethodGet, "/v1/categories/1", employee, nil)
+}
+
+func TestProductRoutes(t *testing.T) {
+	s := newTestServer(t)
+	employee := s.signIn(roleEmployee)
+	admin := s.signIn(roleAdmin)
+
+	s.ok(http.StatusCreated, http.MethodPost, "/v1/categories/", admin, categories.Category{Title: "food"}, nil)
+
+	names := []string{"Coffee", "Milk Coffee", "Steak"}
+	for i, name := range names {
+		req := products.ProductRequest{Name: name, Desc: "a food product", Price: float64(100 * (i + 1)), CategoryID: 1}
+		product := products.Product{}
+		s.ok(http.StatusCreated, http.MethodPost, "/v1/products/", admin, req, &product)
+		if product.ProductID == "" || product.Name != name {
+			t.Fatalf("create returned %+v", product)
+		}
+	}
+	s.fail(http.StatusBadRequest, "products-001", http.MethodPost, "/v1/products/", admin, "{")
+	s.fail(http.StatusUnprocessableEntity, "products-001", http.MethodPost, "/v1/products/", admin, products.ProductRequest{Name: "Free", CategoryID: 1})
+	s.fail(http.StatusUnprocessableEntity, "products-001", http.MethodPost, "/v1/products/", admin, products.ProductRequest{Name: "Orphan", Price: 1, CategoryID: 9})
+	s.fail(http.StatusForbidden, "middlewares-002", http.MethodPost, "/v1/products/", employee, products.ProductRequest{Name: "Tea", Price: 1, CategoryID: 1})
+
+	// every invalid field is listed, not just the first one
+	env := s.fail(http.StatusUnprocessableEntity, "products-001", http.MethodPost, "/v1/products/", admin, products.ProductRequest{Price: 10, Discount: 20, CategoryID: 1})
+	want := []errs.FieldError{{Field: "name", Reason: "is required"}, {Field: "discount", Reason: "must not be greater than price"}}
+	if !reflect.DeepEqual(env.Error.Fields, want) {
+		t.Fatalf("fields %+v, want %+v", env.Error.Fields, want)
+	}
+	env = s.fail(http.StatusUnprocessableEntity, "products-001", http.MethodPost, "/v1/products/", admin, products.ProductRequest{Name: "Orphan", Price: 1, CategoryID: 9})
+	if len(env.Error.Fields) != 1 || env.Error.Fields[0].Field != "categoryId" {
+		t.Fatalf("fields %+v", env.Error.Fields)
+	}
+
+	stocked := products.Product{}
+	s.ok(http.StatusCreated, http.MethodPost, "/v1/products/", admin, map[string]any{"name": "Tea", "price": 1, "stock": 5, "categoryId": 1}, &stocked)
+	if stocked.Stock != 5 {
+		t.Fatalf("create stored stock %d, want 5", stocked.Stock)
+	}
+	s.with("If-Match", `"1"`).ok(http.StatusNoContent, http.MethodDelete, "/v1/products/"+stocked.ProductID, admin, nil, nil)
+
+	list := make([]*products.Product, 0)
+	env = s.ok(http.StatusOK, http.MethodGet, "/v1/products/?sort=price&order=desc&limit=2", employee, nil, &list)
+	if len(list) != 2 || list[0].Name != "Steak" {
+		t.Fatalf("list returned %+v", list)
+	}
+	if p := env.Pagination; p == nil || p.Page != 1 || p.Limit != 2 || p.TotalItems != 3 || p.TotalPages != 2 {
+		t.Fatalf("pagination %+v", env.Pagination)
+	}
+
+	s.ok(http.StatusOK, http.MethodGet, "/v1/products/?minPrice=150&maxPrice=250", employee, nil, &list)
+	if len(list) != 1 || list[0].Name != "Milk Coffee" {
+		t.Fatalf("price filter returned %+v", list)
+	}
+	s.fail(http.StatusUnprocessableEntity, "products-003", http.MethodGet, "/v1/products/?minPrice=300&maxPrice=100", employee, nil)
+	s.fail(http.StatusBadRequest, "products-003", http.MethodGet, "/v1/products/?page=abc", employee, nil)
+
+	env = s.ok(http.StatusOK, http.MethodGet, "/v1/products/search?q=cof", employee, nil, &list)
+	if len(list) != 2 || env.Pagination.TotalItems != 2 {
+		t.Fatalf("search returned %+v", list)
+	}
+	s.ok(http.StatusOK, http.MethodGet, "/v1/products/search?q=milk+cof", employee, nil, &list)
+	if len(list) != 1 || list[0].Name != "Milk Coffee" {
+		t.Fatalf("search returned %+v", list)
+	}
+	s.fail(http.StatusUnprocessableEntity, "products-006", http.MethodGet, "/v1/products/search?q=+", employee, nil)
+
+	// Thai vowels and tone marks are combining marks inside the word
+	thai := products.Product{}
+	s.ok(http.StatusCreated, http.MethodPost, "/v1/products/", admin, products.ProductRequest{Name: "ชาเขียว", Price: 45, CategoryID: 1}, &thai)
+	for q, want := range map[string]int{"ชาเขียว": 1, "ชาเขี": 1, "ยว": 0} {
+		s.ok(http.StatusOK, http.MethodGet, "/v1/products/search?q="+url.QueryEscape(q), employee, nil, &list)
+		if len(list) != want || (want == 1 && list[0].ProductID != thai.ProductID) {
+			t.Fatalf("search %q returned %+v", q, list)
+		}
+	}
+	s.with("If-Match", `"1"`).ok(http.StatusNoContent, http.MethodDelete, "/v1/products/"+thai.ProductID, admin, nil, nil)
+
+	product := products.Product{}
+	s.ok(http.StatusOK, http.MethodGet, "/v1/products/P000001", employee, nil, &product)
+	if product.Name != "Coffee" {
+		t.Fatalf("get returned %+v", product)
+	}
+	s.fail(http.StatusNotFound, "products-002", http.MethodGet, "/v1/products/P999999", employee, nil)
+
+	s.with("If-Match", `"1"`).ok(http.StatusOK, http.MethodPatch, "/v1/products/P000001", admin, map[string]any{"price": 120, "discount": 10}, &product)
+	if product.Price != 120 || product.Discount != 10 || product.Name != "Coffee" || product.Desc != "a food product" {
+		t.Fatalf("update returned %+v", product)
+	}
+
+	// explicit zero and null values are written, absent fields are kept
+	s.ok(http.StatusCreated, http.MethodPost, "/v1/categories/", admin, categories.Category{Title: "drink"}, nil)
+	s.with("If-Match", `"2"`).ok(http.StatusOK, http.MethodPatch, "/v1/products/P000001", admin, map[string]any{"discount": 0, "desc": nil, "categoryId": 2}, &product)
+	if product.Discount != 0 || product.Desc != "" || product.CategoryID != 2 || product.Price != 120 {
+		t.Fatalf("update returned %+v", product)
+	}
+
+	s.with("If-Match", `"3"`).fail(http.StatusBadRequest, "products-004", http.MethodPatch, "/v1/products/P000001", admin, "{")
+	env = s.with("If-Match", `"3"`).fail(http.StatusUnprocessableEntity, "products-004", http.MethodPatch, "/v1/products/P000001", admin, map[string]any{"discount": 121})
+	if len(env.Error.Fields) != 1 || env.Error.Fields[0].Field != "discount" {
+		t.Fatalf("fields %+v", env.Error.Fields)
+	}
+	s.with("If-Match", `"3"`).fail(http.StatusUnprocessableEntity, "products-004", http.MethodPatch, "/v1/products/P000001", admin, map[string]any{"name": nil})
+
+	// stock only moves through a stock adjustment, which writes the log
+	env = s.with("If-Match", `"3"`).fail(http.StatusUnprocessableEntity, "products-004", http.MethodPatch, "/v1/products/P000001", admin, map[string]any{"stock": 0})
+	if len(env.Error.Fields) != 1 || env.Error.Fields[0].Field != "stock" {
+		t.Fatalf("fields %+v", env.Error.Fields)
+	}
+	s.with("If-Match", `"3"`).fail(http.StatusUnprocessableEntity, "products-004", http.MethodPatch, "/v1/products/P000001", admin, map[string]any{"categoryId": 9})
+	s.with("If-Match", `"1"`).fail(http.StatusNotFound, "products-004", http.MethodPatch, "/v1/products/P999999", admin, map[string]any{"price": 1})
+
+	receive := inventories.StockAdjustmentRequest{Type: inventories.AdjustReceive, Quantity: 2}
+	s.ok(http.StatusCreated, http.MethodPost, "/v1/products/P000001/stock", admin, receive, nil)
+
+	replace := products.ProductRequest{Name: "Espresso", Price: 90, CategoryID: 1}
+	s.with("If-Match", `"4"`).ok(http.StatusOK, http.MethodPut, "/v1/products/P000001", admin, replace, &product)
+	if product.Name != "Espresso" || product.Price != 90 || product.CategoryID != 1 || product.Desc != "" || product.Stock != 2 {
+		t.Fatalf("replace returned %+v", product)
+	}
+	s.ok(http.StatusOK, http.MethodGet, "/v1/products/search?q=cof", employee, nil, &list)
+	if len(list) != 1 || list[0].Name != "Milk Coffee" {
+		t.Fatalf("search after a rename returned %+v", list)
+	}
+	s.with("If-Match", `"5"`).fail(http.StatusUnprocessableEntity, "products-007", http.MethodPut, "/v1/products/P000001", admin, products.ProductRequest{Name: "Espresso", CategoryID: 1})
+	s.with("If-Match", `"5"`).fail(http.StatusUnprocessableEntity, "products-007", http.MethodPut, "/v1/products/P000001", admin, map[string]any{"name": "Espresso", "price": 90, "stock": 3, "categoryId": 1})
+	s.with("If-Match", `"1"`).fail(http.StatusNotFound, "products-007", http.MethodPut, "/v1/products/P999999", admin, replace)
+
+	s.with("If-Match", `"1"`).ok(http.StatusNoContent, http.MethodDelete, "/v1/products/P000003", admin, nil, nil)
+	s.with("If-Match", `"1"`).fail(http.StatusNotFound, "products-005", http.MethodDelete, "/v1/products/P000003", admin, nil)
+	s.fail(http.StatusNotFound, "products-002", http.MethodGet, "/v1/products/P000003", employee, nil)
+	s.ok(http.StatusOK, http.MethodGet, "/v1/products/search?q=steak", employee, nil, &list)
+	if len(list) != 0 {
+		t.Fatalf("search after a delete returned %+v", list)
+	}
+}
+
+func TestProductConcurrency(t *testing.T) {
+	s := newTestServer(t)
+	employee := s.signIn(roleEmployee)
+	admin := s.signIn(roleAdmin)
+
+	s.ok(http.StatusCreated, http.MethodPost, "/v1/categories/", admin, categories.Category{Title: "food"}, nil)
+	s.ok(http.StatusCreated, http.MethodPost, "/v1/products/", admin, products.ProductRequest{Name: "Coffee", Price: 50, CategoryID: 1}, nil)
+
+	get := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/v1/products/P000001", nil)
+		req.Header.Set("Authorization", "Bearer "+employee)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		rec := httptest.NewRecorder()
+		s.router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := get("")
+	if rec.Code != http.StatusOK || rec.Header().Get("ETag") != `"1"` {
+		t.Fatalf("status %d, etag %q", rec.Code, rec.Header().Get("ETag"))
+	}
+	if rec = get(`"1"`); rec.Code != http.StatusNotModified || rec.Body.Len() != 0 {
+		t.Fatalf("status %d, body %s", rec.Code, rec.Body.String())
+	}
+	if rec = get(`"0", W/"1"`); rec.Code != http.StatusNotModified {
+		t.Fatalf("status %d for a weak match", rec.Code)
+	}
+
+	// two cashiers read version 1, the second write is stale
+	first, second := s.with("If-Match", `"1"`), s.with("If-Match", `"1"`)
+	first.ok(http.StatusOK, http.MethodPatch, "/v1/products/P000001", admin, map[string]any{"price": 60}, nil)
+	second.fail(http.StatusPreconditionFailed, "products-004", http.MethodPatch, "/v1/products/P000001", admin, map[string]any{"price": 70})
+	second.fail(http.StatusPreconditionFailed, "products-007", http.MethodPut, "/v1/products/P000001", admin, products.ProductRequest{Name: "Tea", Price: 70, CategoryID: 1})
+	second.fail(http.StatusPreconditionFailed, "products-005", http.MethodDelete, "/v1/products/P000001", admin, nil)
+	if rec = get(`"1"`); rec.Code != http.StatusOK || rec.Header().Get("ETag") != `"2"` {
+		t.Fatalf("status %d, etag %q after a write", rec.Code, rec.Header().Get("ETag"))
+	}
+
+	s.fail(http.StatusPreconditionRequired, "products-004", http.MethodPatch, "/v1/products/P000001", admin, map[string]any{"price": 70})
+	s.fail(http.StatusPreconditionRequired, "products-005", http.MethodDelete, "/v1/products/P000001", admin, nil)
+	s.with("If-Match", "2").fail(http.StatusPreconditionFailed, "products-004", http.MethodPatch, "/v1/products/P000001", admin, map[string]any{"price": 70})
+
+	// stock changes move the version on as well
+	receive := inventories.StockAdjustmentRequest{Type: inventories.AdjustReceive, Quantity: 10}
+	s.ok(http.StatusCreated, http.MethodPost, "/v1/products/P000001/stock", admin, receive, nil)
+	s.with("If-Match", `"2"`).fail(http.StatusPreconditionFailed, "products-004", http.MethodPatch, "/v1/products/P000001", admin, map[string]any{"price": 70})
+
+	product := products.Product{}
+	s.with("If-Match", "*").ok(http.StatusOK, http.MethodPatch, "/v1/products/P000001", admin, map[string]any{"price": 70}, &product)
+	if product.Price != 70 || product.Stock != 10 || product.Version != 4 {
+		t.Fatalf("update returned %+v", product)
+	}
+	s.with("If-Match", `"4"`).ok(http.StatusNoContent, http.MethodDelete, "/v1/products/P000001", admin, nil, nil)
+}
+
+func TestInventoryRoutes(t *testing.T) {
+	s := newTestServer(t)
+	employee := s.signIn(roleEmployee)
+	admin := s.signIn(roleAdmin)
+
+	s.ok(http.StatusCreated, http.MethodPost, "/v1/categories/", admin, categories.Category{Title: "food"}, nil)
+	s.ok(http.StatusCreated, http.MethodPost, "/v1/products/", admin, products.ProductRequest{Name: "Coffee", Price: 50, CategoryID: 1}, nil)
+
+	adjustment := inventories.StockAdjustment{}
+	recount := inventories.StockAdjustmentRequest{Type: inventories.AdjustRecount, Quantity: 10}
+	s.ok(http.StatusCreated, http.MethodPost, "/v1/products/P000001/stock", admin, recount, &adjustment)
+	if adjustment.Stock != 10 || adjustment.Log == nil || adjustment.Log.InventoryLogId == "" {
+		t.Fatalf("adjust returned %+v", adjustment)
+	}
+
+	damage := inventories.StockAdjustmentRequest{Type: inventories.AdjustDamage, Quantity: 3, Description: "dropped"}
+	s.ok(http.StatusCreated, http.MethodPost, "/v1/products/P000001/stock", admin, damage, &adjustment)
+	if adjustment.Stock != 7 || adjustment.Log.Change != "-3" {
+		t.Fatalf("adjust returned %+v", adjustment)
+	}
+
+	product := map[string]any{}
+	s.ok(http.StatusOK, http.MethodGet, "/v1/products/P000001", employee, nil, &product)
+	if product["stock"] != float64(7) {
+		t.Fatalf("product stock is %v, want 7", product["stock"])
+	}
+
+	s.fail(http.StatusConflict, "inventories-001", http.MethodPost, "/v1/products/P000001/stock", admin, inventories.StockAdjustmentRequest{Type: inventories.AdjustDamage, Quantity: 99})
+	env := s.fail(http.StatusUnprocessableEntity, "inventories-001", http.MethodPost, "/v1/products/P000001/stock", admin, inventories.StockAdjustmentRequest{Type: "LOST", Quantity: 1})
+	s.fields(env, "type")
+	env = s.fail(http.StatusUnprocessableEntity, "inventories-001", http.MethodPost, "/v1/products/P000001/stock", admin, inventories.StockAdjustmentRequest{Type: inventories.AdjustReceive})
+	s.fields(env, "quantity")
+	receive := inventories.StockAdjustmentRequest{Type: inventories.AdjustReceive, Quantity: 10}
+	s.fail(http.StatusNotFound, "inventories-001", http.MethodPost, "/v1/products/P999999/stock", admin, receive)
+	s.fail(http.StatusBadRequest, "inventories-001", http.MethodPost, "/v1/products/P000001/stock", admin, "{")
+	s.fail(http.StatusForbidden, "middlewares-002", http.MethodPost, "/v1/products/P000001/stock", employee, receive)
+
+	logs := make([]*inventories.InventoryLog, 0)
+	env = s.ok(http.StatusOK, http.MethodGet, "/v1/products/P000001/inventory-logs?limit=1", employee, nil, &logs)
+	if len(logs) != 1 || logs[0].Change != "-3" || env.Pagination.TotalItems != 2 || env.Pagination.TotalPages != 2 {
+		t.Fatalf("logs returned %+v, pagination %+v", logs, env.Pagination)
+	}
+	s.fail(http.StatusBadRequest, "inventories-002", http.MethodGet, "/v1/products/P000001/inventory-logs?limit=abc", employee, nil)
+}
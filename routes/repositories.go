@@ -0,0 +1,79 @@
+package routes
+
+import (
+	"github.com/codepnw/sales-api/database"
+	catrepositories "github.com/codepnw/sales-api/modules/categories/repositories"
+	custrepositories "github.com/codepnw/sales-api/modules/customers/repositories"
+	invrepositories "github.com/codepnw/sales-api/modules/inventories/repositories"
+	mwrepositories "github.com/codepnw/sales-api/modules/middlewares/repositories"
+	orderrepositories "github.com/codepnw/sales-api/modules/orders/repositories"
+	payrepositories "github.com/codepnw/sales-api/modules/payments/repositories"
+	prodrepositories "github.com/codepnw/sales-api/modules/products/repositories"
+	userrepositories "github.com/codepnw/sales-api/modules/users/repositories"
+	"github.com/jmoiron/sqlx"
+)
+
+// repositories holds the implementation of every module for one driver,
+// modules without an implementation for that driver stay nil.
+type repositories struct {
+	product    prodrepositories.IProductRepo
+	category   catrepositories.ICategoryRepo
+	user       userrepositories.IUserRepo
+	middleware mwrepositories.IMiddlewareRepo
+	customer   custrepositories.ICustomerRepo
+	order      orderrepositories.IOrderRepo
+	payment    payrepositories.IPaymentRepo
+	inventory  invrepositories.IInventoryRepo
+}
+
+func newRepositories(driver string) *repositories {
+	if driver == database.DriverMysql {
+		db := database.GetMysqlDB()
+
+		return &repositories{
+			product:    prodrepositories.NewProductMysqlRepository(db),
+			category:   catrepositories.NewCategoryMysqlRepository(db),
+			user:       userrepositories.NewUserMysqlRepository(db),
+			middleware: mwrepositories.NewMiddlewareMysqlRepository(db),
+		}
+	}
+
+	if driver == database.DriverMemory {
+		db := database.GetMemoryDB()
+
+		return &repositories{
+			product:    prodrepositories.NewProductMemoryRepository(db),
+			category:   catrepositories.NewCategoryMemoryRepository(db),
+			user:       userrepositories.NewUserMemoryRepository(db),
+			middleware: mwrepositories.NewMiddlewareMemoryRepository(db),
+			customer:   custrepositories.NewCustomerMemoryRepository(db),
+			order:      orderrepositories.NewOrderMemoryRepository(db),
+			payment:    payrepositories.NewPaymentMemoryRepository(db),
+			inventory:  invrepositories.NewInventoryMemoryRepository(db),
+		}
+	}
+
+	// SQLite runs the PostgreSQL queries, apart from product search
+	if driver == database.DriverSqlite {
+		db := database.GetSqliteDB()
+		repos := sqlxRepositories(db)
+		repos.product = prodrepositories.NewProductSqliteRepository(db)
+
+		return repos
+	}
+
+	return sqlxRepositories(database.GetPostgresDB())
+}
+
+func sqlxRepositories(db *sqlx.DB) *repositories {
+	return &repositories{
+		product:    prodrepositories.NewProductRepository(db),
+		category:   catrepositories.NewCategoryRepository(db),
+		user:       userrepositories.NewUserRepository(db),
+		middleware: mwrepositories.NewMiddlewareRepository(db),
+		customer:   custrepositories.NewCustomerRepository(db),
+		order:      orderrepositories.NewOrderRepository(db),
+		payment:    payrepositories.NewPaymentRepository(db),
+		inventory:  invrepositories.NewInventoryRepository(db),
+	}
+}
@@ -1,45 +1,177 @@
 package routes
 
 import (
+	"github.com/codepnw/sales-api/config"
 	"github.com/codepnw/sales-api/database"
 	cathandlers "github.com/codepnw/sales-api/modules/categories/handlers"
 	catrepositories "github.com/codepnw/sales-api/modules/categories/repositories"
 	catservices "github.com/codepnw/sales-api/modules/categories/services"
+	custhandlers "github.com/codepnw/sales-api/modules/customers/handlers"
+	custrepositories "github.com/codepnw/sales-api/modules/customers/repositories"
+	custservices "github.com/codepnw/sales-api/modules/customers/services"
+	invhandlers "github.com/codepnw/sales-api/modules/inventories/handlers"
+	invrepositories "github.com/codepnw/sales-api/modules/inventories/repositories"
+	invservices "github.com/codepnw/sales-api/modules/inventories/services"
+	"github.com/codepnw/sales-api/modules/middlewares"
+	mwhandlers "github.com/codepnw/sales-api/modules/middlewares/handlers"
+	mwrepositories "github.com/codepnw/sales-api/modules/middlewares/repositories"
+	mwservices "github.com/codepnw/sales-api/modules/middlewares/services"
+	monitorhandlers "github.com/codepnw/sales-api/modules/monitors/handlers"
+	orderhandlers "github.com/codepnw/sales-api/modules/orders/handlers"
+	orderrepositories "github.com/codepnw/sales-api/modules/orders/repositories"
+	orderservices "github.com/codepnw/sales-api/modules/orders/services"
+	payhandlers "github.com/codepnw/sales-api/modules/payments/handlers"
+	payrepositories "github.com/codepnw/sales-api/modules/payments/repositories"
+	payservices "github.com/codepnw/sales-api/modules/payments/services"
 	prodhandlers "github.com/codepnw/sales-api/modules/products/handlers"
 	prodrepositories "github.com/codepnw/sales-api/modules/products/repositories"
 	prodservices "github.com/codepnw/sales-api/modules/products/services"
+	userhandlers "github.com/codepnw/sales-api/modules/users/handlers"
+	userrepositories "github.com/codepnw/sales-api/modules/users/repositories"
+	userservices "github.com/codepnw/sales-api/modules/users/services"
+	"github.com/codepnw/sales-api/pkg/logs"
+	"github.com/codepnw/sales-api/pkg/metrics"
+	"github.com/codepnw/sales-api/pkg/utils"
 	"github.com/gin-gonic/gin"
 )
 
-func Setup(router *gin.Engine, version string) {
-	productRoutes(router, version)
-	categoryRoutes(router, version)
+func Setup(router *gin.Engine, cfg config.IConfig) {
+	version := cfg.App().Version()
+	repos := newRepositories(cfg.DB().Driver())
+	mw := middlewareHandler(cfg.Jwt(), repos.middleware)
+
+	// recovery runs last so a panic still reaches the access log and the
+	// metrics as a 500 with its request id
+	router.Use(utils.RequestId(), utils.AccessLog(), metrics.Middleware(), gin.Recovery())
+	monitorRoutes(router, version, cfg, mw)
+	productRoutes(router, version, mw, repos.product)
+	categoryRoutes(router, version, mw, repos.category)
+	userRoutes(router, version, cfg.Jwt(), mw, repos.user)
+
+	// the remaining modules have no MySQL repositories so far, under MySQL
+	// the API is products, categories, users and the monitors only, the
+	// customers, orders, payments and stock routes answer 404
+	if cfg.DB().Driver() == database.DriverMysql {
+		logs.Warn("mysql driver serves a reduced api, the customers, orders, payments and inventories routes need the postgres or sqlite driver")
+		return
+	}
+	customerRoutes(router, version, mw, repos.customer)
+	orderRoutes(router, version, mw, repos.order, repos.payment)
+	paymentRoutes(router, version, mw, repos.payment)
+	inventoryRoutes(router, version, mw, repos.inventory)
+}
+
+func middlewareHandler(cfg config.ConfigJwt, repo mwrepositories.IMiddlewareRepo) mwhandlers.IMiddlewareHandler {
+	srv := mwservices.NewMiddlewareService(repo)
+	return mwhandlers.NewMiddlewareHandler(srv, cfg)
+}
+
+// monitorRoutes sit outside the version prefix and need no token, they are
+// hit by the Kubernetes probes and the Prometheus scraper. Only the admin
+// routes are versioned and need a superadmin.
+func monitorRoutes(router *gin.Engine, version string, cfg config.IConfig, mw mwhandlers.IMiddlewareHandler) {
+	h := monitorhandlers.NewMonitorHandler(cfg)
+
+	pool, err := database.Pool(cfg.DB().Driver())
+	if err != nil {
+		logs.Error(err)
+	}
+	metrics.RegisterDB(pool, cfg.DB().Driver())
+
+	router.GET("/healthz", h.Health)
+	router.GET("/readyz", h.Ready)
+	router.GET("/version", h.Version)
+	router.GET("/metrics", metrics.Handler())
+
+	g := router.Group(version+"/admin", mw.JwtAuth(), mw.Authorize(middlewares.RoleSuperAdmin))
+	g.GET("/log-level", h.GetLogLevel)
+	g.PUT("/log-level", h.SetLogLevel)
 }
 
-func productRoutes(router *gin.Engine, version string) {
-	repo := prodrepositories.NewProductRepository(database.GetPostgresDB())
+func productRoutes(router *gin.Engine, version string, mw mwhandlers.IMiddlewareHandler, repo prodrepositories.IProductRepo) {
 	srv := prodservices.NewProductService(repo)
 	h := prodhandlers.NewProductHandler(srv)
-	g := router.Group(version + "/products")
+	g := router.Group(version+"/products", mw.JwtAuth())
 	paramId := "/:productId"
 
-	g.POST("/", h.CreateProduct)
-	g.GET("/", h.GetProducts)
-	g.GET(paramId, h.GetProduct)
-	g.PATCH(paramId, h.UpdateProduct)
-	g.DELETE(paramId, h.DeleteProduct)
+	g.POST("/", mw.Authorize(middlewares.RoleAdmin), h.CreateProduct)
+	g.GET("/", mw.Authorize(middlewares.RoleEmployee), h.GetProducts)
+	g.GET("/search", mw.Authorize(middlewares.RoleEmployee), h.SearchProducts)
+	g.GET(paramId, mw.Authorize(middlewares.RoleEmployee), h.GetProduct)
+	g.PATCH(paramId, mw.Authorize(middlewares.RoleAdmin), h.UpdateProduct)
+	g.PUT(paramId, mw.Authorize(middlewares.RoleAdmin), h.ReplaceProduct)
+	g.DELETE(paramId, mw.Authorize(middlewares.RoleAdmin), h.DeleteProduct)
 }
 
-func categoryRoutes(router *gin.Engine, version string) {
-	repo := catrepositories.NewCategoryRepository(database.GetPostgresDB())
+func categoryRoutes(router *gin.Engine, version string, mw mwhandlers.IMiddlewareHandler, repo catrepositories.ICategoryRepo) {
 	srv := catservices.NewCategoryService(repo)
 	h := cathandlers.NewCategoryHandler(srv)
-	g := router.Group(version + "/categories")
+	g := router.Group(version+"/categories", mw.JwtAuth())
 	paramId := "/:categoryId"
 
-	g.POST("/", h.CreateCategory)
-	g.GET("/", h.GetAllCategory)
-	g.GET(paramId, h.GetOneCategory)
-	g.PATCH(paramId, h.UpdateCategory)
-	g.DELETE(paramId, h.DeleteCategory)
+	g.POST("/", mw.Authorize(middlewares.RoleAdmin), h.CreateCategory)
+	g.GET("/", mw.Authorize(middlewares.RoleEmployee), h.GetAllCategory)
+	g.GET(paramId, mw.Authorize(middlewares.RoleEmployee), h.GetOneCategory)
+	g.PATCH(paramId, mw.Authorize(middlewares.RoleAdmin), h.UpdateCategory)
+	g.PUT(paramId, mw.Authorize(middlewares.RoleAdmin), h.ReplaceCategory)
+	g.DELETE(paramId, mw.Authorize(middlewares.RoleAdmin), h.DeleteCategory)
+}
+
+func userRoutes(router *gin.Engine, version string, cfg config.ConfigJwt, mw mwhandlers.IMiddlewareHandler, repo userrepositories.IUserRepo) {
+	srv := userservices.NewUserService(repo, cfg)
+	h := userhandlers.NewUserHandler(srv)
+	g := router.Group(version + "/users")
+
+	// staff accounts are created by an admin, an open sign up would hand
+	// the employee role to anyone who can reach the API
+	g.POST("/signup", mw.JwtAuth(), mw.Authorize(middlewares.RoleAdmin), h.SignUp)
+	g.POST("/signin", h.SignIn)
+	g.POST("/refresh", h.RefreshToken)
+	g.POST("/signout", mw.JwtAuth(), h.SignOut)
+}
+
+func customerRoutes(router *gin.Engine, version string, mw mwhandlers.IMiddlewareHandler, repo custrepositories.ICustomerRepo) {
+	srv := custservices.NewCustomerService(repo)
+	h := custhandlers.NewCustomerHandler(srv)
+	g := router.Group(version+"/customers", mw.JwtAuth(), mw.Authorize(middlewares.RoleEmployee))
+	paramId := "/:customerId"
+
+	g.POST("/", h.CreateCustomer)
+	g.GET("/", h.GetCustomers)
+	g.GET("/phone/:phone", h.GetCustomerByPhone)
+	g.GET("/email/:email", h.GetCustomerByEmail)
+	g.GET(paramId, h.GetCustomer)
+	g.PATCH(paramId, h.UpdateCustomer)
+	g.DELETE(paramId, mw.Authorize(middlewares.RoleAdmin), h.DeleteCustomer)
+}
+
+func orderRoutes(router *gin.Engine, version string, mw mwhandlers.IMiddlewareHandler, repo orderrepositories.IOrderRepo, payRepo payrepositories.IPaymentRepo) {
+	srv := orderservices.NewOrderService(repo, payRepo)
+	h := orderhandlers.NewOrderHandler(srv)
+	g := router.Group(version+"/orders", mw.JwtAuth(), mw.Authorize(middlewares.RoleEmployee))
+	paramId := "/:orderId"
+
+	g.POST("/", h.CreateOrder)
+	g.GET("/", h.GetOrders)
+	g.GET(paramId, h.GetOrder)
+	g.PATCH(paramId+"/status", h.UpdateOrderStatus)
+}
+
+func paymentRoutes(router *gin.Engine, version string, mw mwhandlers.IMiddlewareHandler, repo payrepositories.IPaymentRepo) {
+	srv := payservices.NewPaymentService(repo)
+	h := payhandlers.NewPaymentHandler(srv)
+	g := router.Group(version+"/orders/:orderId", mw.JwtAuth(), mw.Authorize(middlewares.RoleEmployee))
+
+	g.POST("/payments", h.CreatePayment)
+	g.GET("/payments", h.GetPayments)
+	g.GET("/balance", h.GetBalance)
+}
+
+func inventoryRoutes(router *gin.Engine, version string, mw mwhandlers.IMiddlewareHandler, repo invrepositories.IInventoryRepo) {
+	srv := invservices.NewInventoryService(repo)
+	h := invhandlers.NewInventoryHandler(srv)
+	g := router.Group(version+"/products/:productId", mw.JwtAuth())
+
+	g.POST("/stock", mw.Authorize(middlewares.RoleAdmin), h.AdjustStock)
+	g.GET("/inventory-logs", mw.Authorize(middlewares.RoleEmployee), h.GetInventoryLogs)
 }
@@ -0,0 +1,406 @@
+package routes
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/codepnw/sales-api/config"
+	"github.com/codepnw/sales-api/database"
+	"github.com/codepnw/sales-api/modules/users"
+	"github.com/codepnw/sales-api/pkg/errs"
+	"github.com/codepnw/sales-api/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	roleEmployee   int = 1
+	roleAdmin      int = 2
+	roleSuperAdmin int = 3
+
+	testPassword string = "P@ssw0rd"
+)
+
+const testConfig = `
+app:
+  port: ":0"
+  version: "/v1"
+db:
+  driver: memory
+jwt:
+  access_key: test-access-key
+  refresh_key: test-refresh-key
+  access_expires: 600
+  refresh_expires: 3600
+`
+
+var testCfg config.IConfig
+
+// testDriver is the database newTestServer connects, TestSqliteRoutes
+// switches it while it re-runs the suite.
+var testDriver = database.DriverMemory
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+
+	dir, err := os.MkdirTemp("", "sales-api-routes")
+	if err != nil {
+		panic(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "app_config.yaml"), []byte(testConfig), 0o600); err != nil {
+		panic(err)
+	}
+	testCfg = config.InitConfig(dir, "app_config")
+
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+type envelope struct {
+	Data       json.RawMessage   `json:"data"`
+	Pagination *utils.Pagination `json:"pagination"`
+	Error      *struct {
+		TraceId   string            `json:"trace_id"`
+		RequestId string            `json:"request_id"`
+		Message   string            `json:"message"`
+		Fields    []errs.FieldError `json:"fields"`
+	} `json:"error"`
+}
+
+type testServer struct {
+	t      *testing.T
+	router *gin.Engine
+	users  int
+	header http.Header
+}
+
+// newTestServer builds the real routes on a fresh database of testDriver.
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+
+	cfg := testCfg
+	if testDriver == database.DriverSqlite {
+		cfg = newSqliteConfig(t)
+	}
+	if err := database.Connect(cfg); err != nil {
+		t.Fatal(err)
+	}
+	if testDriver == database.DriverSqlite {
+		migrateSqlite(t)
+	}
+
+	router := gin.New()
+	Setup(router, cfg)
+
+	return &testServer{t: t, router: router}
+}
+
+func (s *testServer) do(method, path, token string, body any) (int, *envelope) {
+	s.t.Helper()
+
+	var reader *bytes.Reader
+	switch b := body.(type) {
+	case nil:
+		reader = bytes.NewReader(nil)
+	case string:
+		reader = bytes.NewReader([]byte(b))
+	default:
+		raw, err := json.Marshal(b)
+		if err != nil {
+			s.t.Fatal(err)
+		}
+		reader = bytes.NewReader(raw)
+	}
+
+	req := httptest.NewRequest(method, path, reader)
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	for key, values := range s.header {
+		req.Header[key] = values
+	}
+
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, req)
+
+	env := &envelope{}
+	if rec.Body.Len() > 0 {
+		if err := json.Unmarshal(rec.Body.Bytes(), env); err != nil {
+			s.t.Fatalf("%s %s: body is not a json envelope: %s", method, path, rec.Body.String())
+		}
+	}
+
+	return rec.Code, env
+}
+
+// with returns a copy of s that also sends the header key on every request.
+func (s *testServer) with(key, value string) *testServer {
+	header := s.header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set(key, value)
+
+	copied := *s
+	copied.header = header
+	return &copied
+}
+
+// ok expects a {"data":...} response with status and decodes data into out.
+func (s *testServer) ok(status int, method, path, token string, body, out any) *envelope {
+	s.t.Helper()
+
+	code, env := s.do(method, path, token, body)
+	if code != status {
+		s.t.Fatalf("%s %s: status %d, want %d, body %+v", method, path, code, status, env.Error)
+	}
+	if env.Error != nil {
+		s.t.Fatalf("%s %s: unexpected error %+v", method, path, env.Error)
+	}
+
+	if status == http.StatusNoContent {
+		return env
+	}
+	if env.Data == nil {
+		s.t.Fatalf("%s %s: response has no data", method, path)
+	}
+	if out != nil {
+		if err := json.Unmarshal(env.Data, out); err != nil {
+			s.t.Fatalf("%s %s: decode data: %v", method, path, err)
+		}
+	}
+
+	return env
+}
+
+// fail expects a {"error":{"trace_id","request_id","message"}} response with status and trace id.
+func (s *testServer) fail(status int, traceId, method, path, token string, body any) *envelope {
+	s.t.Helper()
+
+	code, env := s.do(method, path, token, body)
+	if code != status {
+		s.t.Fatalf("%s %s: status %d, want %d", method, path, code, status)
+	}
+	if env.Error == nil {
+		s.t.Fatalf("%s %s: response has no error", method, path)
+	}
+	if env.Error.TraceId != traceId {
+		s.t.Fatalf("%s %s: trace_id %q, want %q", method, path, env.Error.TraceId, traceId)
+	}
+	if env.Error.Message == "" {
+		s.t.Fatalf("%s %s: error has no message", method, path)
+	}
+	if env.Error.RequestId == "" {
+		s.t.Fatalf("%s %s: error has no request_id", method, path)
+	}
+	if env.Data != nil {
+		s.t.Fatalf("%s %s: error response also has data", method, path)
+	}
+
+	return env
+}
+
+// fields expects the 422 in env to list exactly the fields named, in order.
+func (s *testServer) fields(env *envelope, names ...string) {
+	s.t.Helper()
+
+	got := make([]string, 0, len(env.Error.Fields))
+	for _, f := range env.Error.Fields {
+		if f.Reason == "" {
+			s.t.Fatalf("field %s has no reason", f.Field)
+		}
+		got = append(got, f.Field)
+	}
+	if !reflect.DeepEqual(got, names) {
+		s.t.Fatalf("fields %+v, want %v", env.Error.Fields, names)
+	}
+}
+
+// signIn creates a user with roleId straight in the repository, sign up only
+// gives employees, and returns the access token from /users/signin.
+func (s *testServer) signIn(roleId int) string {
+	s.t.Helper()
+
+	s.users++
+	hashed, err := bcrypt.GenerateFromPassword([]byte(testPassword), bcrypt.MinCost)
+	if err != nil {
+		s.t.Fatal(err)
+	}
+
+	user := &users.User{
+		Email:     fmt.Sprintf("user%d@mail.com", s.users),
+		Username:  fmt.Sprintf("user%d", s.users),
+		Password:  string(hashed),
+		RoleId:    roleId,
+		CreatedAt: utils.LocalTime(),
+		UpdatedAt: utils.LocalTime(),
+	}
+	if _, err := newRepositories(testDriver).user.CreateUser(user); err != nil {
+		s.t.Fatal(err)
+	}
+
+	passport := users.UserPassport{}
+	body := users.UserSignInRequest{Email: user.Email, Password: testPassword}
+	s.ok(http.StatusOK, http.MethodPost, "/v1/users/signin", "", body, &passport)
+
+	return passport.Token.AccessToken
+}
+
+func TestUserRoutes(t *testing.T) {
+	s := newTestServer(t)
+	admin := s.signIn(roleAdmin)
+
+	signUp := users.UserSignUpRequest{Email: "new@mail.com", Username: "newuser", Password: testPassword}
+	user := users.User{}
+	s.fail(http.StatusUnauthorized, "middlewares-001", http.MethodPost, "/v1/users/signup", "", signUp)
+	s.fail(http.StatusForbidden, "middlewares-002", http.MethodPost, "/v1/users/signup", s.signIn(roleEmployee), signUp)
+	s.ok(http.StatusCreated, http.MethodPost, "/v1/users/signup", admin, signUp, &user)
+	if user.UserId == "" || user.RoleId != users.DefaultRoleId {
+		t.Fatalf("signup returned %+v", user)
+	}
+
+	s.fail(http.StatusConflict, "users-001", http.MethodPost, "/v1/users/signup", admin, signUp)
+	env := s.fail(http.StatusUnprocessableEntity, "users-001", http.MethodPost, "/v1/users/signup", admin, users.UserSignUpRequest{})
+	s.fields(env, "email", "username", "password")
+	env = s.fail(http.StatusUnprocessableEntity, "users-001", http.MethodPost, "/v1/users/signup", admin, users.UserSignUpRequest{Email: "new", Username: "new", Password: testPassword})
+	s.fields(env, "email")
+	s.fail(http.StatusBadRequest, "users-001", http.MethodPost, "/v1/users/signup", admin, "{")
+
+	signIn := users.UserSignInRequest{Email: signUp.Email, Password: testPassword}
+	passport := users.UserPassport{}
+	s.ok(http.StatusOK, http.MethodPost, "/v1/users/signin", "", signIn, &passport)
+	if passport.Token == nil || passport.Token.AccessToken == "" || passport.User.UserId != user.UserId {
+		t.Fatalf("signin returned %+v", passport)
+	}
+
+	s.fail(http.StatusUnauthorized, "users-002", http.MethodPost, "/v1/users/signin", "", users.UserSignInRequest{Email: signUp.Email, Password: "wrong"})
+	s.fail(http.StatusBadRequest, "users-002", http.MethodPost, "/v1/users/signin", "", "{")
+	s.fields(s.fail(http.StatusUnprocessableEntity, "users-002", http.MethodPost, "/v1/users/signin", "", users.UserSignInRequest{}), "email", "password")
+
+	token := users.UserToken{}
+	refresh := users.UserRefreshRequest{RefreshToken: passport.Token.RefreshToken}
+	s.ok(http.StatusOK, http.MethodPost, "/v1/users/refresh", "", refresh, &token)
+	if token.OauthId != passport.Token.OauthId || token.AccessToken == "" {
+		t.Fatalf("refresh returned %+v", token)
+	}
+
+	s.fail(http.StatusUnauthorized, "users-003", http.MethodPost, "/v1/users/refresh", "", users.UserRefreshRequest{RefreshToken: "invalid"})
+	s.fail(http.StatusBadRequest, "users-003", http.MethodPost, "/v1/users/refresh", "", "{")
+
+	signOut := users.UserSignOutRequest{OauthId: token.OauthId}
+	s.fail(http.StatusUnauthorized, "middlewares-001", http.MethodPost, "/v1/users/signout", "", signOut)
+	s.fail(http.StatusBadRequest, "users-004", http.MethodPost, "/v1/users/signout", token.AccessToken, "{")
+	s.fail(http.StatusUnprocessableEntity, "users-004", http.MethodPost, "/v1/users/signout", token.AccessToken, users.UserSignOutRequest{OauthId: "not-a-uuid"})
+
+	// another user can't end this session, it looks like it doesn't exist
+	other := s.signIn(roleEmployee)
+	s.fail(http.StatusNotFound, "users-004", http.MethodPost, "/v1/users/signout", other, signOut)
+	s.ok(http.StatusNoContent, http.MethodPost, "/v1/users/signout", token.AccessToken, signOut, nil)
+
+	// the oauth row is gone, so the token no longer authenticates
+	s.fail(http.StatusUnauthorized, "middlewares-001", http.MethodPost, "/v1/users/signout", token.AccessToken, signOut)
+}
+
+func TestMiddlewares(t *testing.T) {
+	s := newTestServer(t)
+	employee := s.signIn(roleEmployee)
+	admin := s.signIn(roleAdmin)
+
+	s.fail(http.StatusUnauthorized, "middlewares-001", http.MethodGet, "/v1/products/", "", nil)
+	s.fail(http.StatusUnauthorized, "middlewares-001", http.MethodGet, "/v1/products/", "not-a-jwt", nil)
+
+	s.ok(http.StatusOK, http.MethodGet, "/v1/products/", employee, nil, nil)
+	s.fail(http.StatusForbidden, "middlewares-002", http.MethodPost, "/v1/categories/", employee, map[string]string{"title": "food"})
+	s.ok(http.StatusCreated, http.MethodPost, "/v1/categories/", admin, map[string]string{"title": "food"}, nil)
+
+	// a higher role includes every lower one
+	superAdmin := s.signIn(roleSuperAdmin)
+	s.ok(http.StatusOK, http.MethodGet, "/v1/customers/", superAdmin, nil, nil)
+}
+
+func TestRequestId(t *testing.T) {
+	s := newTestServer(t)
+
+	send := func(requestId string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/v1/products/", nil)
+		if requestId != "" {
+			req.Header.Set(utils.HeaderRequestId, requestId)
+		}
+		rec := httptest.NewRecorder()
+		s.router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := send("abc-123")
+	if got := rec.Header().Get(utils.HeaderRequestId); got != "abc-123" {
+		t.Fatalf("request id %q, want the one sent", got)
+	}
+	env := envelope{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &env); err != nil || env.Error == nil || env.Error.RequestId != "abc-123" {
+		t.Fatalf("error envelope %s", rec.Body.String())
+	}
+
+	// a missing or malformed id is replaced by a generated one
+	for _, requestId := range []string{"", "bad id\n{}"} {
+		got := send(requestId).Header().Get(utils.HeaderRequestId)
+		if got == "" || got == requestId {
+			t.Fatalf("request id %q for %q", got, requestId)
+		}
+	}
+}
+
+func TestProblemJSON(t *testing.T) {
+	s := newTestServer(t)
+	admin := s.signIn(roleAdmin)
+
+	send := func(method, path, accept, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, bytes.NewReader([]byte(body)))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+admin)
+		req.Header.Set("Accept", accept)
+		rec := httptest.NewRecorder()
+		s.router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := send(http.MethodGet, "/v1/categories/99", "application/problem+json, application/json;q=0.5", "")
+	if rec.Code != http.StatusNotFound || rec.Header().Get("Content-Type") != utils.MIMEProblemJSON {
+		t.Fatalf("status %d, content type %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	problem := map[string]any{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &problem); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{"type": "about:blank", "title": "Not Found", "status": float64(404), "instance": "/v1/categories/99", "code": "category-002"}
+	for k, v := range want {
+		if problem[k] != v {
+			t.Fatalf("%s is %v, want %v in %s", k, problem[k], v, rec.Body.String())
+		}
+	}
+	if problem["detail"] == "" || problem["requestId"] == "" {
+		t.Fatalf("problem %s", rec.Body.String())
+	}
+
+	rec = send(http.MethodPost, "/v1/categories/", utils.MIMEProblemJSON, `{"desc":"no title"}`)
+	if rec.Code != http.StatusUnprocessableEntity || !bytes.Contains(rec.Body.Bytes(), []byte(`"fields":[{"field":"title"`)) {
+		t.Fatalf("status %d, body %s", rec.Code, rec.Body.String())
+	}
+
+	// everyone else keeps the envelope
+	for _, accept := range []string{"", "*/*", "application/json"} {
+		rec = send(http.MethodGet, "/v1/categories/99", accept, "")
+		env := envelope{}
+		if err := json.Unmarshal(rec.Body.Bytes(), &env); err != nil || env.Error == nil || env.Error.TraceId != "category-002" {
+			t.Fatalf("accept %q: body %s", accept, rec.Body.String())
+		}
+	}
+}
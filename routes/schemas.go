@@ -0,0 +1,33 @@
+package routes
+
+import (
+	"github.com/codepnw/sales-api/database"
+	catrepositories "github.com/codepnw/sales-api/modules/categories/repositories"
+	custrepositories "github.com/codepnw/sales-api/modules/customers/repositories"
+	invrepositories "github.com/codepnw/sales-api/modules/inventories/repositories"
+	mwrepositories "github.com/codepnw/sales-api/modules/middlewares/repositories"
+	orderrepositories "github.com/codepnw/sales-api/modules/orders/repositories"
+	payrepositories "github.com/codepnw/sales-api/modules/payments/repositories"
+	prodrepositories "github.com/codepnw/sales-api/modules/products/repositories"
+	userrepositories "github.com/codepnw/sales-api/modules/users/repositories"
+)
+
+// Schemas returns the tables and columns used by every repository wired in Setup.
+func Schemas() []database.TableSchema {
+	schemas := make([]database.TableSchema, 0)
+
+	for _, s := range [][]database.TableSchema{
+		prodrepositories.Schemas,
+		catrepositories.Schemas,
+		userrepositories.Schemas,
+		mwrepositories.Schemas,
+		custrepositories.Schemas,
+		orderrepositories.Schemas,
+		payrepositories.Schemas,
+		invrepositories.Schemas,
+	} {
+		schemas = append(schemas, s...)
+	}
+
+	return schemas
+}
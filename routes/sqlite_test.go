@@ -0,0 +1,139 @@
+package routes
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/codepnw/sales-api/config"
+	"github.com/codepnw/sales-api/database"
+	"github.com/codepnw/sales-api/modules/users"
+)
+
+// sqliteConfig is testCfg pointed at a SQLite file of its own.
+type sqliteConfig struct {
+	config.IConfig
+	db sqliteDB
+}
+
+type sqliteDB struct {
+	config.ConfigDB
+	dsn string
+}
+
+func (c sqliteConfig) DB() config.ConfigDB { return c.db }
+func (d sqliteDB) Driver() string          { return database.DriverSqlite }
+func (d sqliteDB) DSN() string             { return d.dsn }
+
+func newSqliteConfig(t *testing.T) config.IConfig {
+	t.Helper()
+
+	dsn := "file:" + filepath.Join(t.TempDir(), "sales.db")
+	return sqliteConfig{IConfig: testCfg, db: sqliteDB{ConfigDB: testCfg.DB(), dsn: dsn}}
+}
+
+// seedRows are the demo rows of 000002_insert_data, the tests expect an
+// empty shop like the memory driver gives them, roles included.
+const seedRows = `
+DELETE FROM "products";
+DELETE FROM "categories";
+DELETE FROM "users";
+DELETE FROM "sqlite_sequence" WHERE "name" <> 'user_roles';
+`
+
+// migrateSqlite applies the embedded SQLite migrations to the database
+// newTestServer just opened, drops the demo rows and closes it when the
+// test ends.
+func migrateSqlite(t *testing.T) {
+	t.Helper()
+
+	db := database.GetSqliteDB()
+	t.Cleanup(func() { db.Close() })
+
+	migrator, err := database.NewMigrator(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := migrator.Up(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(seedRows); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestSqliteRoutes re-runs the route tests on a migrated SQLite file, so the
+// translated migrations, the FTS5 search triggers and the queries without
+// FOR UPDATE are exercised like the memory repositories are.
+func TestSqliteRoutes(t *testing.T) {
+	suite := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{"Monitor", TestMonitorRoutes},
+		{"User", TestUserRoutes},
+		{"Middlewares", TestMiddlewares},
+		{"Category", TestCategoryRoutes},
+		{"Product", TestProductRoutes},
+		{"ProductConcurrency", TestProductConcurrency},
+		{"Inventory", TestInventoryRoutes},
+		{"Customer", TestCustomerRoutes},
+		{"Order", TestOrderRoutes},
+		{"ConcurrentOrders", TestConcurrentOrders},
+		{"Payment", TestPaymentRoutes},
+	}
+
+	testDriver = database.DriverSqlite
+	defer func() { testDriver = database.DriverMemory }()
+
+	for _, tc := range suite {
+		t.Run(tc.name, tc.test)
+	}
+}
+
+// TestSqliteAuthOutage checks a broken database isn't reported as bad
+// credentials, sign in and refresh answer 500 rather than 401.
+func TestSqliteAuthOutage(t *testing.T) {
+	testDriver = database.DriverSqlite
+	defer func() { testDriver = database.DriverMemory }()
+
+	s := newTestServer(t)
+	s.signIn(roleEmployee)
+
+	passport := users.UserPassport{}
+	signIn := users.UserSignInRequest{Email: "user1@mail.com", Password: testPassword}
+	s.ok(http.StatusOK, http.MethodPost, "/v1/users/signin", "", signIn, &passport)
+
+	if err := database.GetSqliteDB().Close(); err != nil {
+		t.Fatal(err)
+	}
+	s.fail(http.StatusInternalServerError, "users-002", http.MethodPost, "/v1/users/signin", "", signIn)
+	s.fail(http.StatusInternalServerError, "users-003", http.MethodPost, "/v1/users/refresh", "", users.UserRefreshRequest{RefreshToken: passport.Token.RefreshToken})
+}
+
+// recycledDB asks the pool to drop its connections almost at once.
+type recycledDB struct{ config.ConfigDB }
+
+func (d recycledDB) ConnMaxLifetime() time.Duration { return time.Millisecond }
+func (d recycledDB) ConnMaxIdleTime() time.Duration { return time.Millisecond }
+
+// TestSqliteMemoryKept checks an in-memory database outlives the pool
+// timeouts, recycling its only connection would throw the tables away.
+func TestSqliteMemoryKept(t *testing.T) {
+	cfg := sqliteConfig{IConfig: testCfg, db: sqliteDB{ConfigDB: recycledDB{testCfg.DB()}, dsn: "file::memory:"}}
+	if err := database.NewSqliteConnect(cfg); err != nil {
+		t.Fatal(err)
+	}
+	db := database.GetSqliteDB()
+	defer db.Close()
+
+	if _, err := db.Exec(`CREATE TABLE "kept" ("id" INTEGER);`); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if _, err := db.Exec(`SELECT * FROM "kept";`); err != nil {
+		t.Fatal(err)
+	}
+}